@@ -1,6 +1,9 @@
 package delve
 
 import (
+	"iter"
+
+	"github.com/vloldik/delve/v3/internal/jsonpath"
 	"github.com/vloldik/delve/v3/internal/quals"
 	"github.com/vloldik/delve/v3/internal/value"
 	"github.com/vloldik/delve/v3/pkg/idelve"
@@ -11,6 +14,13 @@ import (
 // Use the Get/QGet methods to obtain Value instances from a Navigator.
 type Value = value.Value
 
+// Coercer is a lenient accessor of a Value returned by Value.Coerce, which parses strings
+// into numbers, booleans and durations and formats scalars into strings.
+type Coercer = value.Coercer
+
+// CoerceOptions configures Value.Coerce.
+type CoerceOptions = value.CoerceOptions
+
 // IterList provides a type-safe way to iterate over a slice contained within a `Value`.
 //
 // It requires the `Value` to hold a slice of type `[]V`. If the `Value` contains
@@ -67,6 +77,42 @@ func IterMap[K comparable, V any](val *Value, callback func(K, V) bool) {
 	value.IterMap(val, callback)
 }
 
+// Elements returns an iterator over keys and elements of a map, list, Go struct or custom
+// source held by val (see Value.Items) converted to T by the rules of Value getters: values of
+// type T are used as is and numbers are converted unless the conversion is lossy. Elements which
+// can't be converted are yielded as the zero value of T.
+//
+// Example:
+//
+//	for i, port := range delve.Elements[int](nav.Get("ports")) {
+//	    fmt.Println(i, port)
+//	}
+func Elements[T any](val *Value) iter.Seq2[string, T] {
+	return value.Elements[T](val)
+}
+
+// SliceOf returns the slice held by val as []T. Elements of other slices and arrays
+// (e.g. []any from encoding/json) are converted by the rules of Value getters (see Elements).
+// Returns the default (nil if not provided) if val is not a slice or any element can't be converted.
+//
+// Example:
+//
+//	ids := delve.SliceOf[uint64](nav.Get("ids"))
+func SliceOf[T any](val *Value, _default ...[]T) []T {
+	return value.SliceOf(val, _default...)
+}
+
+// MapOf returns the string-keyed map held by val as map[string]T. Values of other maps
+// (e.g. map[string]any from encoding/json) are converted by the rules of Value getters.
+// Returns the default (nil if not provided) if val is not a string-keyed map or any value can't be converted.
+//
+// Example:
+//
+//	limits := delve.MapOf[float64](nav.Get("limits"))
+func MapOf[T any](val *Value, _default ...map[string]T) map[string]T {
+	return value.MapOf(val, _default...)
+}
+
 // Q creates a new qualifier from a string path using optional delimiters.
 // Default delimiter is '.'. Qualifiers can be reused for multiple operations.
 //
@@ -96,3 +142,61 @@ func Q(qual string, _delimiter ...rune) idelve.IQual {
 func CQ(qual string, _delimiter ...rune) idelve.IQual {
 	return quals.CQ(qual, _delimiter...)
 }
+
+// Pointer creates a compiled qualifier from an RFC 6901 JSON Pointer such as "/a/b~1c/0".
+// "~1" and "~0" are unescaped to "/" and "~". The "-" token appends to lists like "+"
+// and is a plain key of other containers.
+// Panics if the pointer is invalid, use ParsePointer for untrusted input.
+//
+// Example:
+//
+//	navigator.QSet(delve.Pointer("/user/roles/-"), "viewer")
+func Pointer(pointer string) idelve.IQual {
+	return quals.Pointer(pointer)
+}
+
+// ParsePointer is like Pointer, but returns an error if the pointer is invalid.
+func ParsePointer(pointer string) (idelve.IQual, error) {
+	qual, err := quals.ParsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	return qual, nil
+}
+
+// ToPointer renders any qualifier as an RFC 6901 JSON Pointer.
+//
+// Example:
+//
+//	delve.ToPointer(delve.CQ("a.b/c.0")) // "/a/b~1c/0"
+func ToPointer(qual idelve.IQual) string {
+	return quals.ToPointer(qual)
+}
+
+// Query is a compiled JSONPath expression. Create it with JSONPath and evaluate with Navigator.Query.
+type Query = jsonpath.Query
+
+// JSONPath compiles a JSONPath expression into a Query.
+//
+// Supported syntax: root ($), child (.name, ['name']), wildcards (.*, [*]),
+// array indices ([0], [-1]), slices ([start:end:step]), unions ([0,2]),
+// recursive descent (..name) and filters with comparisons and boolean logic
+// ([?(@.price < 10 && @.category == 'fiction')]).
+//
+// Example:
+//
+//	var cheapTitles = delve.MustJSONPath("$.store.book[?(@.price < 10)].title")
+//	matches := navigator.Query(cheapTitles)
+func JSONPath(expr string) (*Query, error) {
+	return jsonpath.Compile(expr)
+}
+
+// MustJSONPath is like JSONPath but panics if the expression can't be compiled.
+// Useful for package-level query variables.
+func MustJSONPath(expr string) *Query {
+	query, err := jsonpath.Compile(expr)
+	if err != nil {
+		panic(err)
+	}
+	return query
+}
@@ -30,6 +30,21 @@ func BenchmarkDelve(b *testing.B) {
 	}
 }
 
+// Qualifiers are shared by all goroutines, run with -race to check them
+func BenchmarkDelveParallel(b *testing.B) {
+	fm := delve.New(map[string]any{"test": map[string]any{"test": 123}})
+	qual := quals.CQ("test.test")
+	strQual := quals.Q("test.test")
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = fm.QGet(qual).Int()
+			_ = fm.QGet(strQual).Int()
+		}
+	})
+}
+
 func BenchmarkFlexStringLen(b *testing.B) {
 	baseStr := "1"
 	for n := 0; n < 10; n++ {
@@ -0,0 +1,54 @@
+package delve
+
+import (
+	"github.com/vloldik/delve/v3/internal/quals"
+	"github.com/vloldik/delve/v3/internal/value"
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
+
+// RegisterConverter registers convert as the way to get values of type T with GetAs and ValueAs,
+// replacing a converter registered for T before (a nil convert removes it). The converter is
+// called with every non-nil value, including values of type T, and its result is used instead
+// of plain type assertion; if it returns an error, the default is returned.
+// Converters are global and safe to register concurrently, usually from init functions.
+//
+// Example:
+//
+//	delve.RegisterConverter(func(v any) (uuid.UUID, error) {
+//	    s, ok := v.(string)
+//	    if !ok {
+//	        return uuid.Nil, fmt.Errorf("unexpected %T", v)
+//	    }
+//	    return uuid.Parse(s)
+//	})
+func RegisterConverter[T any](convert func(any) (T, error)) {
+	value.RegisterConverter(convert)
+}
+
+// ValueAs returns the value held by val as T. The converter registered for T by RegisterConverter
+// is used if there is one. Otherwise values of type T are returned as is, numbers are converted
+// by the rules of numeric getters and strings or []byte are unmarshaled if T (or *T) implements
+// encoding.TextUnmarshaler (e.g. netip.Addr, time.Time, *big.Int).
+// Returns the default (or the zero value of T) if val is nil or can't be converted.
+//
+// Example:
+//
+//	addr := delve.ValueAs[netip.Addr](nav.Get("server.addr"))
+func ValueAs[T any](val *Value, _default ...T) T {
+	return value.As(val, _default...)
+}
+
+// QGetAs retrieves the value at the qualified path as T. See ValueAs.
+func QGetAs[T any](nav Navigator, qual idelve.IQual, _default ...T) T {
+	return ValueAs(nav.QGet(qual), _default...)
+}
+
+// GetAs retrieves the value at the string-qualified path (delimited by '.') as T. See ValueAs.
+// Use QGetAs with Q to choose another delimiter.
+//
+// Example:
+//
+//	level := delve.GetAs(nav, "log.level", slog.LevelInfo)
+func GetAs[T any](nav Navigator, path string, _default ...T) T {
+	return QGetAs(nav, quals.Q(path), _default...)
+}
@@ -0,0 +1,153 @@
+package delve
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/vloldik/delve/v3/internal/defaultval"
+	"github.com/vloldik/delve/v3/internal/quals"
+	"github.com/vloldik/delve/v3/internal/sources"
+	"github.com/vloldik/delve/v3/internal/value"
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
+
+// ChangeKind is the kind of a difference found by Diff.
+type ChangeKind uint8
+
+const (
+	// Added means the value exists only in the second navigator
+	Added ChangeKind = iota + 1
+	// Removed means the value exists only in the first navigator
+	Removed
+	// Modified means the values differ
+	Modified
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Modified:
+		return "modified"
+	default:
+		return "unknown change " + strconv.Itoa(int(k))
+	}
+}
+
+// Change is a single difference between two navigators.
+type Change struct {
+	Kind ChangeKind
+	// Path is a compiled qualifier of the changed value
+	Path idelve.IQual
+	// Old is the value in the first navigator (nil-value container for Added)
+	Old *value.Value
+	// New is the value in the second navigator (nil-value container for Removed)
+	New *value.Value
+}
+
+// Changes is a list of differences returned by Diff.
+type Changes []Change
+
+// DiffOptions configures Diff.
+type DiffOptions struct {
+	// NumericEqual treats numbers of different types as equal if they represent
+	// the same value (e.g. float64(1) from JSON and int(1) from Go).
+	NumericEqual bool
+}
+
+// Diff returns structural differences between a and b. Maps are compared by keys
+// (in sorted order) and lists by indices, so the result describes how to turn a into b.
+// Navigators over custom sources which don't implement Range are compared as values.
+// Data of synchronized navigators is copied under their read locks first.
+//
+// Example:
+//
+//	for _, change := range delve.Diff(oldConfig, newConfig, delve.DiffOptions{NumericEqual: true}) {
+//	    log.Printf("%v %v: %v -> %v", change.Kind, change.Path, change.Old.Interface(), change.New.Interface())
+//	}
+func Diff(a, b Navigator, _opts ...DiffOptions) Changes {
+	d := &differ{opts: defaultval.WithDefaultEmpty(_opts)}
+	d.diff(nil, a.lockedRoot(), b.lockedRoot())
+	return d.changes
+}
+
+// Patch converts changes to an RFC 6902 JSON Patch which turns the first navigator
+// passed to Diff into the second one. Values are copied.
+func (c Changes) Patch() []PatchOperation {
+	ops := make([]PatchOperation, 0, len(c))
+	for _, change := range c {
+		op := PatchOperation{Path: quals.ToPointer(change.Path)}
+		switch change.Kind {
+		case Added:
+			op.Op, op.Value = "add", value.DeepCopy(change.New.Interface())
+		case Removed:
+			op.Op = "remove"
+		default:
+			op.Op, op.Value = "replace", value.DeepCopy(change.New.Interface())
+		}
+		ops = append(ops, op)
+	}
+	return ops
+}
+
+type differ struct {
+	opts    DiffOptions
+	changes Changes
+}
+
+func (d *differ) add(kind ChangeKind, path []string, old, new any) {
+	d.changes = append(d.changes, Change{Kind: kind, Path: quals.FromParts(path), Old: value.New(old), New: value.New(new)})
+}
+
+func (d *differ) diff(path []string, a, b any) {
+	aSource, bSource := sources.GetSource(a), sources.GetSource(b)
+	_, aOk := aSource.(sources.Ranger)
+	_, bOk := bSource.(sources.Ranger)
+	if !aOk || !bOk || sources.IsList(aSource) != sources.IsList(bSource) {
+		if !d.equal(a, b) {
+			d.add(Modified, path, a, b)
+		}
+		return
+	}
+	aItems, bItems := sources.Items(aSource, true), sources.Items(bSource, true)
+
+	if sources.IsList(aSource) {
+		common := min(len(aItems), len(bItems))
+		for i := 0; i < common; i++ {
+			d.diff(appendPath(path, aItems[i].Key), aItems[i].Val, bItems[i].Val)
+		}
+		// Removals go from the end, so that the patch never shifts pending indices
+		for i := len(aItems) - 1; i >= common; i-- {
+			d.add(Removed, appendPath(path, aItems[i].Key), aItems[i].Val, nil)
+		}
+		for i := common; i < len(bItems); i++ {
+			d.add(Added, appendPath(path, bItems[i].Key), nil, bItems[i].Val)
+		}
+		return
+	}
+
+	i, j := 0, 0
+	for i < len(aItems) || j < len(bItems) {
+		switch {
+		case j == len(bItems) || i < len(aItems) && aItems[i].Key < bItems[j].Key:
+			d.add(Removed, appendPath(path, aItems[i].Key), aItems[i].Val, nil)
+			i++
+		case i == len(aItems) || bItems[j].Key < aItems[i].Key:
+			d.add(Added, appendPath(path, bItems[j].Key), nil, bItems[j].Val)
+			j++
+		default:
+			d.diff(appendPath(path, aItems[i].Key), aItems[i].Val, bItems[j].Val)
+			i++
+			j++
+		}
+	}
+}
+
+func (d *differ) equal(a, b any) bool {
+	if d.opts.NumericEqual {
+		return value.DeepEqual(a, b)
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,259 @@
+package delve
+
+import (
+	"strconv"
+
+	"github.com/vloldik/delve/v3/internal/quals"
+	"github.com/vloldik/delve/v3/internal/sources"
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
+
+// undoKind describes how an undo entry restores data
+type undoKind uint8
+
+const (
+	undoPut    undoKind = iota // store value at path
+	undoRemove                 // remove value at path
+	undoInsert                 // insert value before list index at path
+	undoSource                 // restore root source
+)
+
+// undoEntry restores a single change made to navigator data
+type undoEntry struct {
+	kind   undoKind
+	path   []string
+	value  any
+	source idelve.ISource
+	// nav is the navigator the path is relative to, nil for the navigator passed to rollback
+	nav *navigator
+}
+
+// undoLog records changes made by edit operations so they can be reverted.
+// A nil *undoLog records nothing.
+type undoLog []undoEntry
+
+func (log *undoLog) add(entry undoEntry) {
+	if log != nil {
+		*log = append(*log, entry)
+	}
+}
+
+// rollback reverts recorded changes in reverse order
+func (log undoLog) rollback(fm *navigator) {
+	for i := len(log) - 1; i >= 0; i-- {
+		entry := log[i]
+		target := fm
+		if entry.nav != nil {
+			target = entry.nav
+		}
+		switch entry.kind {
+		case undoPut:
+			target.editPut(entry.path, entry.value)
+		case undoRemove:
+			target.editRemove(entry.path, nil)
+		case undoInsert:
+			target.editAdd(entry.path, entry.value, nil)
+		case undoSource:
+			target.source = entry.source
+		}
+	}
+}
+
+// record appends changes made through fm to the log of the navigator, if it has one.
+// Entries already bound to a navigator (e.g. by a sub-navigator of a committed transaction) keep it.
+func (fm *navigator) record(changes undoLog) {
+	if fm.log == nil {
+		return
+	}
+	for _, entry := range changes {
+		if entry.nav == nil {
+			entry.nav = fm
+		}
+		*fm.log = append(*fm.log, entry)
+	}
+}
+
+// unbind resets entries bound to fm, so they are relative to the navigator the log is recorded to next
+func (log undoLog) unbind(fm *navigator) {
+	for i := range log {
+		if log[i].nav == fm {
+			log[i].nav = nil
+		}
+	}
+}
+
+// replaceSource replaces the root source recording the previous one
+func (fm *navigator) replaceSource(source idelve.ISource, log *undoLog) {
+	log.add(undoEntry{kind: undoSource, source: fm.source})
+	fm.source = source
+}
+
+// editTarget resolves all but the last part of a non-empty path and returns the container
+// holding the last part along with its parent. Unlike qualSet, it never creates intermediate maps.
+func (fm *navigator) editTarget(path []string) (parent idelve.ISource, parentKey string, container idelve.ISource, failure pathFailure) {
+	if container = fm.source; container == nil {
+		return nil, "", nil, pathFailure{reason: NotFound, segment: path[0]}
+	}
+	for i, part := range path[:len(path)-1] {
+		if _, ok := container.Get(part); !ok {
+			return nil, "", nil, getFailure(container, i, part)
+		}
+		inner := sources.Inner(container, part)
+		if inner == nil {
+			return nil, "", nil, pathFailure{reason: NotContainer, index: i + 1, segment: path[i+1]}
+		}
+		parent, parentKey, container = container, part, inner
+	}
+	return parent, parentKey, container, pathFailure{}
+}
+
+// editPut stores val at path, creating the key if it doesn't exist. Used to restore overwritten values.
+func (fm *navigator) editPut(path []string, val any) pathFailure {
+	parent, parentKey, container, failure := fm.editTarget(path)
+	if failure.failed() {
+		return failure
+	}
+	return setSynced(parent, parentKey, container, path[len(path)-1], val, len(path)-1)
+}
+
+// editReplace overwrites the existing value at path
+func (fm *navigator) editReplace(path []string, val any, log *undoLog) pathFailure {
+	parent, parentKey, container, failure := fm.editTarget(path)
+	if failure.failed() {
+		return failure
+	}
+	index, key := len(path)-1, normalizeIndex(container, path[len(path)-1])
+	old, ok := container.Get(key)
+	if !ok {
+		return getFailure(container, index, key)
+	}
+	if failure := setSynced(parent, parentKey, container, key, val, index); failure.failed() {
+		return failure
+	}
+	log.add(undoEntry{kind: undoPut, path: withLast(path, key), value: old})
+	return pathFailure{}
+}
+
+// editAdd inserts val into a list (shifting following elements, "+" appends)
+// or sets a map key, overwriting the existing value.
+func (fm *navigator) editAdd(path []string, val any, log *undoLog) pathFailure {
+	parent, parentKey, container, failure := fm.editTarget(path)
+	if failure.failed() {
+		return failure
+	}
+	index, key := len(path)-1, normalizeIndex(container, path[len(path)-1])
+
+	inserter, ok := container.(sources.Inserter)
+	if !ok {
+		return setLogged(parent, parentKey, container, withLast(path, key), val, log)
+	}
+	prevLen := sourceLen(container)
+	if !inserter.Insert(key, val) {
+		return setFailure(container, index, key)
+	}
+	if !syncResized(parent, parentKey, container, prevLen) {
+		return pathFailure{reason: ReadOnly, index: index - 1, segment: parentKey}
+	}
+	log.add(undoEntry{kind: undoRemove, path: withLast(path, key)})
+	return pathFailure{}
+}
+
+// editSet sets a map key or overwrites a list element ("+" appends)
+func (fm *navigator) editSet(path []string, val any, log *undoLog) pathFailure {
+	parent, parentKey, container, failure := fm.editTarget(path)
+	if failure.failed() {
+		return failure
+	}
+	return setLogged(parent, parentKey, container, path, val, log)
+}
+
+// setLogged sets the last key of path in container recording how to revert it
+func setLogged(parent idelve.ISource, parentKey string, container idelve.ISource, path []string, val any, log *undoLog) pathFailure {
+	index, key := len(path)-1, path[len(path)-1]
+	undoPath := withLast(path, normalizeIndex(container, key))
+	old, existed := container.Get(key)
+	if failure := setSynced(parent, parentKey, container, key, val, index); failure.failed() {
+		return failure
+	}
+	if existed {
+		log.add(undoEntry{kind: undoPut, path: undoPath, value: old})
+	} else {
+		log.add(undoEntry{kind: undoRemove, path: undoPath})
+	}
+	return pathFailure{}
+}
+
+// setAt sets the value at path, an empty path replaces the whole data with a container
+func (fm *navigator) setAt(path []string, val any, log *undoLog) pathFailure {
+	if len(path) > 0 {
+		return fm.editSet(path, val, log)
+	}
+	source := sources.GetSource(val)
+	if source == nil {
+		return pathFailure{reason: NotContainer}
+	}
+	fm.replaceSource(source, log)
+	return pathFailure{}
+}
+
+// getAt returns the value at path, an empty path refers to the whole data
+func (fm *navigator) getAt(path []string) (any, pathFailure) {
+	if len(path) == 0 {
+		if fm.source == nil {
+			return nil, pathFailure{reason: NotFound}
+		}
+		return sources.Unwrap(fm.source), pathFailure{}
+	}
+	return fm.qualResolve(quals.FromParts(path))
+}
+
+// editRemove removes the value at path and returns it
+func (fm *navigator) editRemove(path []string, log *undoLog) (any, pathFailure) {
+	parent, parentKey, container, failure := fm.editTarget(path)
+	if failure.failed() {
+		return nil, failure
+	}
+	index, key := len(path)-1, normalizeIndex(container, path[len(path)-1])
+	old, ok := container.Get(key)
+	if !ok {
+		return nil, getFailure(container, index, key)
+	}
+	deleter, ok := container.(idelve.IDeleter)
+	prevLen := sourceLen(container)
+	if !ok || !deleter.Delete(key) {
+		return nil, pathFailure{reason: ReadOnly, index: index, segment: key}
+	}
+	if !syncResized(parent, parentKey, container, prevLen) {
+		return nil, pathFailure{reason: ReadOnly, index: index - 1, segment: parentKey}
+	}
+	if _, isInserter := container.(sources.Inserter); isInserter {
+		log.add(undoEntry{kind: undoInsert, path: withLast(path, key), value: old})
+	} else {
+		log.add(undoEntry{kind: undoPut, path: withLast(path, key), value: old})
+	}
+	return old, pathFailure{}
+}
+
+// normalizeIndex converts negative and append ("+", "-") indices of a list to absolute ones,
+// so that the change can be reverted after the list length changes.
+func normalizeIndex(container idelve.ISource, key string) string {
+	resizable, ok := container.(sources.Resizable)
+	if !ok || !sources.IsList(container) {
+		return key
+	}
+	if sources.IsAppend(key) {
+		return strconv.Itoa(resizable.Len())
+	}
+	if index, err := strconv.Atoi(key); err == nil && index < 0 {
+		return strconv.Itoa(resizable.Len() + index)
+	}
+	return key
+}
+
+// withLast returns a copy of path with the last part replaced
+func withLast(path []string, last string) []string {
+	result := make([]string, len(path))
+	copy(result, path)
+	result[len(result)-1] = last
+	return result
+}
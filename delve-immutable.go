@@ -0,0 +1,176 @@
+package delve
+
+import (
+	"github.com/vloldik/delve/v3/internal/quals"
+	"github.com/vloldik/delve/v3/internal/sources"
+	"github.com/vloldik/delve/v3/internal/value"
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
+
+// Immutable is a persistent (copy-on-write) navigator. Its data is never modified:
+// QSet and QDelete return a new Immutable which copies only the containers on the
+// changed path and shares all other subtrees with the old one, so holders of the old
+// snapshot never observe the change. The zero value is not usable - create through NewImmutable.
+//
+// Copy-on-write supports maps and slices (including typed ones) and pointers to structs.
+// Struct values, arrays and custom sources can be read, but not changed.
+type Immutable = *immutable
+
+type immutable struct {
+	nav navigator
+}
+
+// NewImmutable creates an Immutable over source. source must not be modified afterwards.
+func NewImmutable[T sourceType](source T) Immutable {
+	return &immutable{nav: navigator{source: sources.GetSource(source)}}
+}
+
+// QGetRaw retrieves a raw value using a qualified path. Returns the value and an existence flag.
+// Returned containers are shared with other snapshots and must not be modified.
+func (im *immutable) QGetRaw(qual idelve.IQual) (any, bool) {
+	return im.nav.qualGet(qual)
+}
+
+// QGet retrieves a qualified path value wrapped in a value.Value container.
+// Returns nil-value container if path doesn't exist.
+func (im *immutable) QGet(qual idelve.IQual) *value.Value {
+	v, _ := im.nav.qualGet(qual)
+	return value.New(v)
+}
+
+// Get retrieves a value using a string-qualified path. Default path delimiter is '.'.
+func (im *immutable) Get(qual string, _delimiter ...rune) *value.Value {
+	return im.QGet(quals.Q(qual, _delimiter...))
+}
+
+// QGetE retrieves a qualified path value. Returns a *PathError if the path can't be resolved.
+func (im *immutable) QGetE(qual idelve.IQual) (*value.Value, error) {
+	return im.nav.QGetE(qual)
+}
+
+// GetE retrieves a value using a string-qualified path. Returns a *PathError if the path can't be resolved.
+func (im *immutable) GetE(qual string, _delimiter ...rune) (*value.Value, error) {
+	return im.nav.GetE(qual, _delimiter...)
+}
+
+// QGetAll retrieves every value matching a qualifier with wildcards. See Navigator.QGetAll.
+func (im *immutable) QGetAll(qual idelve.IQual) []Match {
+	return im.nav.qualGetAll(qual)
+}
+
+// GetAll retrieves every value matching a string-qualified path. See Navigator.QGetAll.
+func (im *immutable) GetAll(qual string, _delimiter ...rune) []Match {
+	return im.QGetAll(quals.Q(qual, _delimiter...))
+}
+
+// Query evaluates a compiled JSONPath query. See Navigator.Query.
+func (im *immutable) Query(query *Query) []Match {
+	return im.nav.Query(query)
+}
+
+// QSet returns a new Immutable with value stored at the qualified path, creating
+// intermediate maps like Navigator.QSet does. The receiver is left untouched.
+// On failure the receiver itself is returned along with false.
+func (im *immutable) QSet(qual idelve.IQual, value any) (Immutable, bool) {
+	root, failure := persistentSet(im.nav.rootValue(), qualParts(qual), 0, value)
+	if failure.failed() {
+		return im, false
+	}
+	return &immutable{nav: navigator{source: sources.GetSource(root)}}, true
+}
+
+// Set is like QSet for a string-qualified path. Default path delimiter is '.'.
+func (im *immutable) Set(qual string, value any, _delimiter ...rune) (Immutable, bool) {
+	return im.QSet(quals.Q(qual, _delimiter...), value)
+}
+
+// QDelete returns a new Immutable without the value at the qualified path.
+// The receiver is left untouched. On failure the receiver itself is returned along with false.
+func (im *immutable) QDelete(qual idelve.IQual) (Immutable, bool) {
+	root, failure := persistentDelete(im.nav.rootValue(), qualParts(qual), 0)
+	if failure.failed() {
+		return im, false
+	}
+	return &immutable{nav: navigator{source: sources.GetSource(root)}}, true
+}
+
+// Delete is like QDelete for a string-qualified path. Default path delimiter is '.'.
+func (im *immutable) Delete(qual string, _delimiter ...rune) (Immutable, bool) {
+	return im.QDelete(quals.Q(qual, _delimiter...))
+}
+
+// persistentSet returns a copy of node with val stored at path[index:].
+// Only the containers on the path are copied.
+func persistentSet(node any, path []string, index int, val any) (any, pathFailure) {
+	copied, source, failure := copyContainer(node, path, index)
+	if failure.failed() {
+		return nil, failure
+	}
+	key := path[index]
+	if index < len(path)-1 {
+		child, exists := source.Get(key)
+		if !exists || sources.GetSource(child) == nil {
+			child = map[string]any{}
+		}
+		if val, failure = persistentSet(child, path, index+1, val); failure.failed() {
+			return nil, failure
+		}
+	}
+	if !source.Set(key, val) {
+		return nil, setFailure(source, index, key)
+	}
+	return copiedRaw(copied, source), pathFailure{}
+}
+
+// persistentDelete returns a copy of node without the value at path[index:].
+// Only the containers on the path are copied.
+func persistentDelete(node any, path []string, index int) (any, pathFailure) {
+	copied, source, failure := copyContainer(node, path, index)
+	if failure.failed() {
+		return nil, failure
+	}
+	key := path[index]
+	child, exists := source.Get(key)
+	if !exists {
+		return nil, getFailure(source, index, key)
+	}
+	if index < len(path)-1 {
+		if sources.GetSource(child) == nil {
+			return nil, pathFailure{reason: NotContainer, index: index + 1, segment: path[index+1]}
+		}
+		newChild, failure := persistentDelete(child, path, index+1)
+		if failure.failed() {
+			return nil, failure
+		}
+		if !source.Set(key, newChild) {
+			return nil, setFailure(source, index, key)
+		}
+		return copiedRaw(copied, source), pathFailure{}
+	}
+	deleter, ok := source.(idelve.IDeleter)
+	if !ok || !deleter.Delete(key) {
+		return nil, pathFailure{reason: ReadOnly, index: index, segment: key}
+	}
+	return copiedRaw(copied, source), pathFailure{}
+}
+
+// copyContainer makes a shallow copy of node holding segment path[index]
+func copyContainer(node any, path []string, index int) (any, idelve.ISource, pathFailure) {
+	copied, ok := sources.ShallowCopy(node)
+	if !ok {
+		reason := ReadOnly
+		if sources.GetSource(node) == nil {
+			reason = NotContainer
+		}
+		return nil, nil, pathFailure{reason: reason, index: index, segment: path[index]}
+	}
+	return copied, sources.GetSource(copied), pathFailure{}
+}
+
+// copiedRaw returns the changed copy, lists may have been reallocated by the source
+func copiedRaw(copied any, source idelve.ISource) any {
+	if resizable, ok := source.(sources.Resizable); ok {
+		return resizable.Raw()
+	}
+	return copied
+}
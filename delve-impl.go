@@ -1,74 +1,228 @@
 package delve
 
 import (
+	"github.com/vloldik/delve/v3/internal/quals"
 	"github.com/vloldik/delve/v3/internal/sources"
 	"github.com/vloldik/delve/v3/pkg/idelve"
 )
 
+// qualGet walks the qualifier and returns the value at its end. Unlike qualResolve,
+// it doesn't describe failures, which keeps the hot read path small.
 func (fm *navigator) qualGet(qual idelve.IQual) (any, bool) {
-	defer qual.Reset()
+	cursor := quals.Iterate(qual)
+	defer cursor.Close()
 
 	var currentGetter idelve.ISource = fm.source
 	if currentGetter == nil {
 		return nil, false
 	}
-	var hasNext bool = true
-	var part string
-
-	for hasNext {
-		part, hasNext = qual.Next()
+	for {
+		part, hasNext := cursor.Next()
 		if !hasNext {
 			return currentGetter.Get(part)
 		}
-		if inner := getInnerGetter(part, currentGetter); inner != nil {
-			currentGetter = inner
-		} else {
+		if currentGetter = getInnerGetter(part, currentGetter); currentGetter == nil {
 			return nil, false
 		}
 	}
-	return nil, false
+}
+
+// lockedGet is qualGet under the read lock. It is kept out of QGet, so QGet can be inlined
+// and the value.Value it returns doesn't escape to the heap.
+func (fm *navigator) lockedGet(qual idelve.IQual) (any, bool) {
+	fm.readLock()
+	defer fm.readUnlock()
+	return fm.qualGet(qual)
+}
+
+// qualResolve walks the qualifier and returns the value at its end or a description
+// of the segment which could not be resolved.
+func (fm *navigator) qualResolve(qual idelve.IQual) (any, pathFailure) {
+	cursor := quals.Iterate(qual)
+	defer cursor.Close()
+
+	var currentGetter idelve.ISource = fm.source
+	part, hasNext := cursor.Next()
+	if currentGetter == nil {
+		return nil, pathFailure{reason: NotFound, segment: part}
+	}
+
+	for i := 0; ; i++ {
+		val, ok := currentGetter.Get(part)
+		if !ok {
+			return nil, getFailure(currentGetter, i, part)
+		}
+		if !hasNext {
+			return val, pathFailure{}
+		}
+		inner := sources.GetSource(val)
+		part, hasNext = cursor.Next()
+		if inner == nil {
+			return nil, pathFailure{reason: NotContainer, index: i + 1, segment: part}
+		}
+		currentGetter = inner
+	}
 }
 
 func (fm *navigator) qualSet(qual idelve.IQual, value any) bool {
-	defer qual.Reset()
+	return !fm.qualSetE(qual, value).failed()
+}
+
+func (fm *navigator) qualSetE(qual idelve.IQual, value any) pathFailure {
+	cursor := quals.Iterate(qual)
+	defer cursor.Close()
 
 	var currentGetter = fm.source
-	if fm.source == nil {
-		return false
+	part, hasNext := cursor.Next()
+	if currentGetter == nil {
+		return pathFailure{reason: ReadOnly, segment: part}
+	}
+	var parent idelve.ISource
+	var parentKey string
+	// Changes are recorded only if the navigator has an undo log
+	var changes *undoLog
+	var path []string
+	if fm.log != nil {
+		var log undoLog
+		changes = &log
+		defer func() { fm.record(log) }()
 	}
-	var hasNext bool = true
-	var part string
 
-	pathExist := true
-	for hasNext {
-		if !pathExist {
-			newGetter := sources.MapSource{}
-			if !currentGetter.Set(part, newGetter) {
-				return false
+	i := 0
+	for ; hasNext; i++ {
+		inner := getInnerGetter(part, currentGetter)
+		if inner == nil {
+			newMap := map[string]any{}
+			if failure := setPart(parent, parentKey, currentGetter, path, part, newMap, i, changes); failure.failed() {
+				return failure
 			}
-			currentGetter = newGetter
-			part, hasNext = qual.Next()
-			continue
-		}
-		part, hasNext = qual.Next()
-		if !hasNext {
-			break
+			// Further changes are inside the new map and are reverted along with it
+			changes = nil
+			inner = sources.MapSource(newMap)
 		}
-		if inner := getInnerGetter(part, currentGetter); inner != nil {
-			currentGetter = inner
-		} else {
-			pathExist = false
+		if changes != nil {
+			path = append(path, part)
 		}
+		parent, parentKey, currentGetter = currentGetter, part, inner
+		part, hasNext = cursor.Next()
 	}
 
-	return currentGetter.Set(part, value)
+	return setPart(parent, parentKey, currentGetter, path, part, value, i, changes)
+}
+
+// setPart sets key (segment number index) of source like setSynced. If changes is not nil,
+// the change is recorded to it, path holds the preceding parts of the qualifier then.
+func setPart(parent idelve.ISource, parentKey string, source idelve.ISource, path []string, key string, val any, index int, changes *undoLog) pathFailure {
+	if changes == nil {
+		return setSynced(parent, parentKey, source, key, val, index)
+	}
+	return setLogged(parent, parentKey, source, appendPath(path, key), val, changes)
+}
+
+// setSynced sets key (segment number index) of source to val and stores the source
+// back into parent if the set has resized it (e.g. append to a nested list).
+func setSynced(parent idelve.ISource, parentKey string, source idelve.ISource, key string, val any, index int) pathFailure {
+	prevLen := sourceLen(source)
+	if !source.Set(key, val) {
+		return setFailure(source, index, key)
+	}
+	if !syncResized(parent, parentKey, source, prevLen) {
+		return pathFailure{reason: ReadOnly, index: index - 1, segment: parentKey}
+	}
+	return pathFailure{}
 }
 
 // getInnerGetter retrieves nested ISource for further access. Returns nil if not successed
 func getInnerGetter(key string, from idelve.ISource) idelve.ISource {
-	result, ok := from.Get(key)
+	return sources.Inner(from, key)
+}
+
+func (fm *navigator) qualDelete(qual idelve.IQual) bool {
+	if fm.log != nil {
+		var changes undoLog
+		_, failure := fm.editRemove(qualParts(qual), &changes)
+		fm.record(changes)
+		return !failure.failed()
+	}
+	cursor := quals.Iterate(qual)
+	defer cursor.Close()
+
+	var currentGetter = fm.source
+	if currentGetter == nil {
+		return false
+	}
+	var parent idelve.ISource
+	var parentKey string
+
+	part, hasNext := cursor.Next()
+	for hasNext {
+		inner := getInnerGetter(part, currentGetter)
+		if inner == nil {
+			return false
+		}
+		parent, parentKey, currentGetter = currentGetter, part, inner
+		part, hasNext = cursor.Next()
+	}
+
+	deleter, ok := currentGetter.(idelve.IDeleter)
 	if !ok {
-		return nil
+		return false
+	}
+	prevLen := sourceLen(currentGetter)
+	if !deleter.Delete(part) {
+		return false
+	}
+	return syncResized(parent, parentKey, currentGetter, prevLen)
+}
+
+// sourceLen returns length of a resizable source or -1 for other sources
+func sourceLen(source idelve.ISource) int {
+	if resizable, ok := source.(sources.Resizable); ok {
+		return resizable.Len()
+	}
+	return -1
+}
+
+// syncResized stores the slice of a resizable source back into its parent if its length
+// has changed since prevLen. Returns false only if the parent rejects the new value.
+func syncResized(parent idelve.ISource, key string, source idelve.ISource, prevLen int) bool {
+	if parent == nil {
+		return true
+	}
+	resizable, ok := source.(sources.Resizable)
+	if !ok || resizable.Len() == prevLen {
+		return true
+	}
+	return parent.Set(key, resizable.Raw())
+}
+
+// segment is a single part of a qualifier with its kind
+type segment struct {
+	text string
+	kind idelve.SegmentKind
+}
+
+// qualSegments splits qual into segments. Kinds are only reported by qualifiers implementing
+// idelve.IStatelessQual or idelve.IPatternQual, parts of other qualifiers are plain keys.
+func qualSegments(qual idelve.IQual) []segment {
+	cursor := quals.Iterate(qual)
+	defer cursor.Close()
+
+	var segments []segment
+	for hasNext := true; hasNext; {
+		var seg segment
+		seg.text, seg.kind, hasNext = cursor.NextSegment()
+		segments = append(segments, seg)
+	}
+	return segments
+}
+
+// qualParts returns texts of all segments of qual
+func qualParts(qual idelve.IQual) []string {
+	segments := qualSegments(qual)
+	parts := make([]string, len(segments))
+	for i, seg := range segments {
+		parts[i] = seg.text
 	}
-	return sources.GetSource(result)
+	return parts
 }
@@ -0,0 +1,57 @@
+package delve
+
+import (
+	"iter"
+
+	"github.com/vloldik/delve/v3/internal/quals"
+	"github.com/vloldik/delve/v3/internal/sources"
+	"github.com/vloldik/delve/v3/internal/value"
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
+
+// All returns an iterator over top-level keys and values of the navigator. See Value.Items.
+// Items are collected under the read lock of a synchronized navigator when the loop starts
+// and yielded after it is released, so the loop body may read and change the navigator.
+//
+// Example:
+//
+//	for key, v := range nav.All() {
+//	    fmt.Println(key, v.Interface())
+//	}
+func (fm *navigator) All() iter.Seq2[string, *value.Value] {
+	return fm.QItems(nil)
+}
+
+// QItems returns an iterator over keys and values of the container at the qualified path
+// (of the navigator itself if qual is nil). Yields nothing if the path doesn't exist or
+// the value can't be enumerated. See All.
+func (fm *navigator) QItems(qual idelve.IQual) iter.Seq2[string, *value.Value] {
+	return func(yield func(string, *value.Value) bool) {
+		for _, item := range fm.lockedItems(fm.lockedSource(qual), false) {
+			if !yield(item.Key, value.New(item.Val)) {
+				return
+			}
+		}
+	}
+}
+
+// Items returns an iterator over keys and values of the container at the string-qualified path.
+// Default path delimiter is '.'. See QItems.
+func (fm *navigator) Items(qual string, _delimiter ...rune) iter.Seq2[string, *value.Value] {
+	return fm.QItems(quals.Q(qual, _delimiter...))
+}
+
+// lockedSource returns the source of the container at qual (fm.source if qual is nil)
+// read under the read lock, nil if there is no container
+func (fm *navigator) lockedSource(qual idelve.IQual) idelve.ISource {
+	fm.readLock()
+	defer fm.readUnlock()
+	if qual == nil {
+		return fm.source
+	}
+	val, ok := fm.qualGet(qual)
+	if !ok {
+		return nil
+	}
+	return sources.GetSource(val)
+}
@@ -0,0 +1,104 @@
+package delve
+
+import (
+	"slices"
+
+	"github.com/vloldik/delve/v3/internal/quals"
+	"github.com/vloldik/delve/v3/internal/sources"
+	"github.com/vloldik/delve/v3/internal/value"
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
+
+// QHas reports whether a value exists at the qualified path (it may be nil).
+func (fm *navigator) QHas(qual idelve.IQual) bool {
+	fm.readLock()
+	defer fm.readUnlock()
+	_, ok := fm.qualGet(qual)
+	return ok
+}
+
+// Has reports whether a value exists at the string-qualified path. See QHas.
+func (fm *navigator) Has(qual string, _delimiter ...rune) bool {
+	return fm.QHas(quals.Q(qual, _delimiter...))
+}
+
+// QKeys returns keys of the container at the qualified path (of the navigator itself if qual is nil):
+// indices of a list in order, sorted keys of a map, struct or custom source implementing
+// idelve.IRanger (or only its Range method). Returns nil if the path doesn't exist or the
+// value can't be enumerated.
+func (fm *navigator) QKeys(qual idelve.IQual) []string {
+	fm.readLock()
+	defer fm.readUnlock()
+	source, ranger, ok := fm.rangerAt(qual)
+	if !ok {
+		return nil
+	}
+	keys := []string{}
+	ranger.Range(func(key string, _ any) bool {
+		keys = append(keys, key)
+		return true
+	})
+	if !sources.IsList(source) {
+		slices.Sort(keys)
+	}
+	return keys
+}
+
+// Keys returns keys of the container at the string-qualified path, an empty path
+// refers to the navigator itself. See QKeys.
+func (fm *navigator) Keys(qual string, _delimiter ...rune) []string {
+	if qual == "" {
+		return fm.QKeys(nil)
+	}
+	return fm.QKeys(quals.Q(qual, _delimiter...))
+}
+
+// QLen returns the number of keys of the container at the qualified path (of the navigator
+// itself if qual is nil). Containers implementing only Range are counted by it, other values
+// have the length reported by Value.Len (-1 if not applicable or the path doesn't exist).
+func (fm *navigator) QLen(qual idelve.IQual) int {
+	fm.readLock()
+	defer fm.readUnlock()
+	if _, ranger, ok := fm.rangerAt(qual); ok {
+		if counted, ok := ranger.(idelve.IRanger); ok {
+			return counted.Len()
+		}
+		n := 0
+		ranger.Range(func(string, any) bool {
+			n++
+			return true
+		})
+		return n
+	}
+	if qual == nil {
+		return -1
+	}
+	val, ok := fm.qualGet(qual)
+	if !ok {
+		return -1
+	}
+	return value.New(val).Len()
+}
+
+// Len returns the number of keys of the container at the string-qualified path, an empty
+// path refers to the navigator itself. See QLen.
+func (fm *navigator) Len(qual string, _delimiter ...rune) int {
+	if qual == "" {
+		return fm.QLen(nil)
+	}
+	return fm.QLen(quals.Q(qual, _delimiter...))
+}
+
+// rangerAt returns the source at qual (fm.source if qual is nil) if it can be enumerated
+func (fm *navigator) rangerAt(qual idelve.IQual) (idelve.ISource, sources.Ranger, bool) {
+	source := fm.source
+	if qual != nil {
+		val, ok := fm.qualGet(qual)
+		if !ok {
+			return nil, nil, false
+		}
+		source = sources.GetSource(val)
+	}
+	ranger, ok := source.(sources.Ranger)
+	return source, ranger, ok
+}
@@ -0,0 +1,81 @@
+package delve
+
+import (
+	"slices"
+
+	"github.com/vloldik/delve/v3/internal/quals"
+	"github.com/vloldik/delve/v3/internal/sources"
+	"github.com/vloldik/delve/v3/internal/value"
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
+
+// Match is a single result of a multi-value lookup.
+type Match struct {
+	// Path is a compiled qualifier of the concrete path to the value
+	Path idelve.IQual
+	// Value is the found value
+	Value *value.Value
+}
+
+// qualGetAll collects all values matching segments of qual
+func (fm *navigator) qualGetAll(qual idelve.IQual) []Match {
+	if fm.source == nil {
+		return nil
+	}
+	segments := qualSegments(qual)
+	// Consecutive recursive segments match the same paths as a single one
+	segments = slices.CompactFunc(segments, func(a, b segment) bool {
+		return a.kind == idelve.RecursiveSegment && b.kind == idelve.RecursiveSegment
+	})
+	var matches []Match
+	collectMatches(fm.source, segments, make([]string, 0, len(segments)), &matches)
+	return matches
+}
+
+func collectMatches(source idelve.ISource, segments []segment, path []string, matches *[]Match) {
+	current, rest := segments[0], segments[1:]
+
+	visit := func(key string, val any) bool {
+		path := append(path, key)
+		if len(rest) == 0 {
+			addMatch(matches, path, val)
+		} else if inner := sources.GetSource(val); inner != nil {
+			collectMatches(inner, rest, path, matches)
+		}
+		return true
+	}
+
+	switch current.kind {
+	case idelve.RecursiveSegment:
+		// Zero levels: match the rest of segments right here
+		if len(rest) > 0 {
+			collectMatches(source, rest, path, matches)
+		}
+		// One or more levels: descend into every child keeping the recursive segment
+		if r, ok := source.(sources.Ranger); ok {
+			r.Range(func(key string, val any) bool {
+				path := append(path, key)
+				if len(rest) == 0 {
+					addMatch(matches, path, val)
+				}
+				if inner := sources.GetSource(val); inner != nil {
+					collectMatches(inner, segments, path, matches)
+				}
+				return true
+			})
+		}
+	case idelve.WildcardSegment:
+		if r, ok := source.(sources.Ranger); ok {
+			r.Range(visit)
+		}
+	default:
+		if val, ok := source.Get(current.text); ok {
+			visit(current.text, val)
+		}
+	}
+}
+
+// addMatch appends a match with a copy of path
+func addMatch(matches *[]Match, path []string, val any) {
+	*matches = append(*matches, Match{Path: quals.FromParts(slices.Clone(path)), Value: value.New(val)})
+}
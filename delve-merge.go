@@ -0,0 +1,251 @@
+package delve
+
+import (
+	"fmt"
+
+	"github.com/vloldik/delve/v3/internal/quals"
+	"github.com/vloldik/delve/v3/internal/sources"
+	"github.com/vloldik/delve/v3/internal/value"
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
+
+// ListStrategy defines how Merge combines two lists found at the same path.
+type ListStrategy uint8
+
+const (
+	// ListReplace replaces the list with the incoming one
+	ListReplace ListStrategy = iota
+	// ListAppend appends incoming elements to the list
+	ListAppend
+	// ListMergeByIndex merges elements with the same index and appends the rest
+	ListMergeByIndex
+	// ListMergeByKey merges map elements having equal values of MergeOptions.ListKey
+	// and appends elements without a match
+	ListMergeByKey
+)
+
+// ConflictStrategy defines how Merge resolves two different non-container values
+// (or a container and a non-container) found at the same path.
+type ConflictStrategy uint8
+
+const (
+	// ConflictOverwrite replaces the value with the incoming one
+	ConflictOverwrite ConflictStrategy = iota
+	// ConflictKeep keeps the existing value
+	ConflictKeep
+	// ConflictError aborts the merge with a *MergeConflictError
+	ConflictError
+)
+
+// MergeOptions configures Navigator.Merge. The zero value replaces lists and overwrites conflicting values.
+type MergeOptions struct {
+	// Lists is the strategy for lists
+	Lists ListStrategy
+	// ListKey is the key field used by ListMergeByKey
+	ListKey string
+	// Conflicts is the strategy for conflicting values
+	Conflicts ConflictStrategy
+}
+
+// MergeConflictError is returned by Merge with the ConflictError strategy.
+type MergeConflictError struct {
+	// Path is the qualifier of the conflicting value
+	Path idelve.IQual
+	// Existing is the value of the navigator being merged into
+	Existing *value.Value
+	// Incoming is the value of the merged navigator
+	Incoming *value.Value
+}
+
+func (e *MergeConflictError) Error() string {
+	return fmt.Sprintf("delve: merge conflict at %v: %v != %v", e.Path, e.Existing.Interface(), e.Incoming.Interface())
+}
+
+// merger carries the state of a single Merge or MergePatch call
+type merger struct {
+	fm   *navigator
+	opts MergeOptions
+	log  undoLog
+}
+
+// run executes merge and reverts all changes if it fails
+func (m *merger) run(merge func() error) error {
+	if err := merge(); err != nil {
+		m.log.rollback(m.fm)
+		return err
+	}
+	m.fm.record(m.log)
+	return nil
+}
+
+func (m *merger) set(path []string, val any) error {
+	return m.fm.setAt(path, value.DeepCopy(val), &m.log).toError(quals.FromParts(path))
+}
+
+func (m *merger) add(path []string, val any) error {
+	return m.fm.editAdd(path, value.DeepCopy(val), &m.log).toError(quals.FromParts(path))
+}
+
+// mergePatch merges an RFC 7386 patch into target located at path
+func (m *merger) mergePatch(path []string, target any, patch any) error {
+	patchMap, ok := patch.(map[string]any)
+	if !ok {
+		return m.set(path, patch)
+	}
+	targetSource := sources.GetSource(target)
+	if targetSource == nil || sources.IsList(targetSource) {
+		newMap := map[string]any{}
+		if failure := m.fm.setAt(path, newMap, &m.log); failure.failed() {
+			return failure.toError(quals.FromParts(path))
+		}
+		targetSource = sources.MapSource(newMap)
+	}
+	for key, val := range patchMap {
+		childPath := appendPath(path, key)
+		existing, exists := targetSource.Get(key)
+		switch {
+		case val == nil && exists:
+			if _, failure := m.fm.editRemove(childPath, &m.log); failure.failed() {
+				return failure.toError(quals.FromParts(childPath))
+			}
+		case val == nil:
+		case exists:
+			if err := m.mergePatch(childPath, existing, val); err != nil {
+				return err
+			}
+		default:
+			if err := m.mergePatch(childPath, nil, val); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// merge merges incoming into target located at path
+func (m *merger) merge(path []string, target, incoming any) error {
+	targetSource, incomingSource := sources.GetSource(target), sources.GetSource(incoming)
+	if targetSource == nil || incomingSource == nil || sources.IsList(targetSource) != sources.IsList(incomingSource) {
+		return m.conflict(path, target, incoming)
+	}
+	incomingRanger, ok := incomingSource.(sources.Ranger)
+	if !ok {
+		return m.conflict(path, target, incoming)
+	}
+	if sources.IsList(targetSource) {
+		return m.mergeLists(path, targetSource, incomingRanger)
+	}
+
+	var err error
+	incomingRanger.Range(func(key string, val any) bool {
+		if existing, exists := targetSource.Get(key); exists {
+			err = m.merge(appendPath(path, key), existing, val)
+		} else {
+			err = m.set(appendPath(path, key), val)
+		}
+		return err == nil
+	})
+	return err
+}
+
+func (m *merger) mergeLists(path []string, target idelve.ISource, incoming sources.Ranger) error {
+	if m.opts.Lists == ListReplace {
+		return m.set(path, sources.Unwrap(incoming.(idelve.ISource)))
+	}
+
+	var err error
+	incoming.Range(func(key string, val any) bool {
+		switch m.opts.Lists {
+		case ListMergeByIndex:
+			if existing, exists := target.Get(key); exists {
+				err = m.merge(appendPath(path, key), existing, val)
+				return err == nil
+			}
+		case ListMergeByKey:
+			if index, existing, found := m.findByKey(path, val); found {
+				err = m.merge(appendPath(path, index), existing, val)
+				return err == nil
+			}
+		}
+		err = m.add(appendPath(path, "+"), val)
+		return err == nil
+	})
+	return err
+}
+
+// findByKey finds an element of the list at path having the same ListKey value as val
+func (m *merger) findByKey(path []string, val any) (string, any, bool) {
+	keySource := sources.GetSource(val)
+	if keySource == nil {
+		return "", nil, false
+	}
+	keyValue, ok := keySource.Get(m.opts.ListKey)
+	if !ok {
+		return "", nil, false
+	}
+	// Read the list again, as appended elements are not visible through a stale list source
+	list, failure := m.fm.getAt(path)
+	listRanger, ok := sources.GetSource(list).(sources.Ranger)
+	if failure.failed() || !ok {
+		return "", nil, false
+	}
+	var foundIndex string
+	var found any
+	var isFound bool
+	listRanger.Range(func(index string, element any) bool {
+		if elementSource := sources.GetSource(element); elementSource != nil {
+			if other, ok := elementSource.Get(m.opts.ListKey); ok && value.DeepEqual(keyValue, other) {
+				foundIndex, found, isFound = index, element, true
+			}
+		}
+		return !isFound
+	})
+	return foundIndex, found, isFound
+}
+
+// conflict resolves different values at the same path
+func (m *merger) conflict(path []string, target, incoming any) error {
+	if value.DeepEqual(target, incoming) {
+		return nil
+	}
+	switch m.opts.Conflicts {
+	case ConflictKeep:
+		return nil
+	case ConflictError:
+		return &MergeConflictError{Path: quals.FromParts(path), Existing: value.New(target), Incoming: value.New(incoming)}
+	}
+	return m.set(path, incoming)
+}
+
+// appendPath returns a copy of path with key appended
+func appendPath(path []string, key string) []string {
+	result := make([]string, len(path)+1)
+	copy(result, path)
+	result[len(path)] = key
+	return result
+}
+
+func (fm *navigator) mergePatch(patch map[string]any) error {
+	m := &merger{fm: fm}
+	return m.run(func() error {
+		return m.mergePatch(nil, fm.rootValue(), patch)
+	})
+}
+
+func (fm *navigator) merge(incoming any, opts MergeOptions) error {
+	if incoming == nil {
+		return nil
+	}
+	m := &merger{fm: fm, opts: opts}
+	return m.run(func() error {
+		return m.merge(nil, fm.rootValue(), incoming)
+	})
+}
+
+// rootValue returns raw data of the navigator or nil if it has no source
+func (fm *navigator) rootValue() any {
+	if fm.source == nil {
+		return nil
+	}
+	return sources.Unwrap(fm.source)
+}
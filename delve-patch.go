@@ -0,0 +1,124 @@
+package delve
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/vloldik/delve/v3/internal/quals"
+	"github.com/vloldik/delve/v3/internal/value"
+)
+
+// ErrTestFailed is returned (wrapped in a *PatchError) when a "test" patch operation doesn't match.
+var ErrTestFailed = errors.New("delve: test operation failed")
+
+// PatchOperation is a single RFC 6902 JSON Patch operation.
+// Paths are JSON Pointers, Value is used by add, replace and test, From by move and copy.
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	From  string `json:"from,omitempty"`
+	Value any    `json:"value"`
+}
+
+// PatchError is returned by ApplyPatch and describes the operation which failed.
+type PatchError struct {
+	// Index is the index of the failed operation
+	Index int
+	// Op is the failed operation
+	Op PatchOperation
+	// Err is the cause, usually a *PathError or ErrTestFailed
+	Err error
+}
+
+func (e *PatchError) Error() string {
+	return fmt.Sprintf("delve: patch operation %d (%s %s) failed: %v", e.Index, e.Op.Op, e.Op.Path, e.Err)
+}
+
+func (e *PatchError) Unwrap() error {
+	return e.Err
+}
+
+func (fm *navigator) applyPatch(ops []PatchOperation) error {
+	var log undoLog
+	for i, op := range ops {
+		if err := fm.applyPatchOp(op, &log); err != nil {
+			log.rollback(fm)
+			return &PatchError{Index: i, Op: op, Err: err}
+		}
+	}
+	fm.record(log)
+	return nil
+}
+
+func (fm *navigator) applyPatchOp(op PatchOperation, log *undoLog) error {
+	path, err := quals.SplitPointer(op.Path)
+	if err != nil {
+		return err
+	}
+
+	switch op.Op {
+	case "add":
+		return pointerError(fm.patchAdd(path, op.Value, log), op.Path)
+	case "remove":
+		if len(path) == 0 {
+			return pointerError(pathFailure{reason: ReadOnly}, op.Path)
+		}
+		_, failure := fm.editRemove(path, log)
+		return pointerError(failure, op.Path)
+	case "replace":
+		if len(path) == 0 {
+			return pointerError(fm.setAt(path, op.Value, log), op.Path)
+		}
+		return pointerError(fm.editReplace(path, op.Value, log), op.Path)
+	case "test":
+		current, failure := fm.getAt(path)
+		if failure.failed() {
+			return pointerError(failure, op.Path)
+		}
+		if !value.DeepEqual(current, op.Value) {
+			return ErrTestFailed
+		}
+		return nil
+	case "move", "copy":
+		from, err := quals.SplitPointer(op.From)
+		if err != nil {
+			return err
+		}
+		if op.Op == "copy" {
+			val, failure := fm.getAt(from)
+			if failure.failed() {
+				return pointerError(failure, op.From)
+			}
+			return pointerError(fm.patchAdd(path, value.DeepCopy(val), log), op.Path)
+		}
+		if len(from) < len(path) && slices.Equal(from, path[:len(from)]) {
+			return fmt.Errorf("delve: can't move %q into its own child %q", op.From, op.Path)
+		}
+		if len(from) == 0 {
+			return pointerError(pathFailure{reason: ReadOnly}, op.From)
+		}
+		val, failure := fm.editRemove(from, log)
+		if failure.failed() {
+			return pointerError(failure, op.From)
+		}
+		return pointerError(fm.patchAdd(path, val, log), op.Path)
+	}
+	return fmt.Errorf("delve: unknown patch operation %q", op.Op)
+}
+
+// patchAdd adds val at path, an empty path replaces the whole document
+func (fm *navigator) patchAdd(path []string, val any, log *undoLog) pathFailure {
+	if len(path) == 0 {
+		return fm.setAt(path, val, log)
+	}
+	return fm.editAdd(path, val, log)
+}
+
+// pointerError converts failure of an operation addressed by a JSON Pointer to an error
+func pointerError(failure pathFailure, pointer string) error {
+	if !failure.failed() {
+		return nil
+	}
+	return failure.toError(quals.Pointer(pointer))
+}
@@ -0,0 +1,39 @@
+package delve
+
+import (
+	"github.com/vloldik/delve/v3/internal/quals"
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
+
+// ReadOnly returns a view of the same data which can't be changed through it: QSet, QDelete
+// and their string variants return false, QSetE, patches and merges return a *PathError with
+// the ReadOnly reason and SetSource (as well as SetMapSource and SetListSource) does nothing.
+// Sub-navigators and transactions of the view are read-only too, whatever the underlying data is.
+// Changes made through the original navigator are visible through the view and reported to its
+// watchers. Values returned by reads and Source are not copied, so they must not be modified directly.
+//
+// Example:
+//
+//	plugin.Configure(config.GetNavigator("plugins." + plugin.Name()).ReadOnly())
+func (fm *navigator) ReadOnly() Navigator {
+	fm.writeLock()
+	defer fm.writeUnlock()
+	// share the registry, so watchers of the view are notified about changes of fm
+	if fm.watchers == nil {
+		fm.watchers = &watchRegistry{root: fm}
+	}
+	return &navigator{source: fm.source, lock: fm.lock, log: fm.log, watchers: fm.watchers, prefix: fm.prefix, readOnly: true}
+}
+
+// IsReadOnly reports whether the navigator is a read-only view. See ReadOnly.
+func (fm *navigator) IsReadOnly() bool {
+	return fm.readOnly
+}
+
+// readOnlyError describes a write refused by a read-only view
+func readOnlyError(qual idelve.IQual) error {
+	cursor := quals.Iterate(qual)
+	defer cursor.Close()
+	segment, _ := cursor.Next()
+	return &PathError{Qual: qual, Index: 0, Segment: segment, Reason: ReadOnly}
+}
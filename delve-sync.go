@@ -0,0 +1,91 @@
+package delve
+
+import (
+	"sync"
+
+	"github.com/vloldik/delve/v3/internal/sources"
+	"github.com/vloldik/delve/v3/internal/value"
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
+
+// NewSync creates a Navigator safe for concurrent use: reads take a shared lock
+// and writes an exclusive one. Use Update for atomic changes of several paths.
+// Values returned by reads (e.g. maps and lists wrapped in value.Value) are not
+// copied, so they must not be modified or read concurrently with writes.
+func NewSync[T sourceType](source T) Navigator {
+	return FromSync(sources.GetSource(source))
+}
+
+// FromSync creates a Navigator safe for concurrent use from an existing ISource implementation.
+// See NewSync.
+func FromSync(source idelve.ISource) Navigator {
+	return &navigator{source: source, lock: &sync.RWMutex{}}
+}
+
+// Update calls update with a navigator over the same data holding the exclusive lock
+// (for synchronized navigators) for the whole call, so other goroutines see either
+// none or all of the changes. If update returns an error, changes made through tx
+// (and its sub-navigators) are reverted and the error is returned.
+// tx must not be used after update returns and the navigator itself must not be used
+// inside update, as it would deadlock.
+//
+// Example:
+//
+//	err := config.Update(func(tx delve.Navigator) error {
+//	    if !tx.Set("db.host", host) || !tx.Set("db.port", port) {
+//	        return errors.New("invalid config")
+//	    }
+//	    return nil
+//	})
+func (fm *navigator) Update(update func(tx Navigator) error) error {
+	tx := fm.Begin()
+	defer func() {
+		// update has panicked
+		if !tx.done {
+			tx.Rollback()
+		}
+	}()
+	if err := update(tx.navigator); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (fm *navigator) readLock() {
+	if fm.lock != nil {
+		fm.lock.RLock()
+	}
+}
+
+func (fm *navigator) readUnlock() {
+	if fm.lock != nil {
+		fm.lock.RUnlock()
+	}
+}
+
+func (fm *navigator) writeLock() {
+	if fm.lock != nil {
+		fm.lock.Lock()
+	}
+}
+
+func (fm *navigator) writeUnlock() {
+	if fm.lock != nil {
+		fm.lock.Unlock()
+	}
+}
+
+// lockedRoot returns data of fm (nil for a nil navigator). Data of a synchronized
+// navigator is copied under the read lock, so it can be used after the lock is released.
+func (fm *navigator) lockedRoot() any {
+	if fm == nil {
+		return nil
+	}
+	if fm.lock == nil {
+		return fm.rootValue()
+	}
+	fm.readLock()
+	defer fm.readUnlock()
+	return value.DeepCopy(fm.rootValue())
+}
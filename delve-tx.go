@@ -0,0 +1,58 @@
+package delve
+
+import "errors"
+
+// ErrTxDone is returned by Commit and Rollback of a transaction which has already been finished.
+var ErrTxDone = errors.New("delve: transaction has already been committed or rolled back")
+
+// Tx is a transaction started by Navigator.Begin. It is a navigator over the same data
+// which records how to undo every change made through it (and its sub-navigators):
+// sets, deletes, intermediate maps created by QSet, patches, merges and source replacements.
+// Changes are applied to the data immediately; Rollback reverts them, Commit keeps them.
+// The transaction must not be used after it is finished.
+type Tx struct {
+	*navigator
+	origin *navigator
+	events *watchEvents
+	done   bool
+}
+
+// Begin starts a transaction. A synchronized navigator stays exclusively locked until the
+// transaction is finished, so the navigator itself must not be used in the meantime.
+//
+// Example:
+//
+//	tx := nav.Begin()
+//	if !tx.Set("a.b", 1) || !tx.Set("list.5", 2) {
+//	    tx.Rollback()
+//	} else {
+//	    tx.Commit()
+//	}
+func (fm *navigator) Begin() *Tx {
+	events := fm.beginWrite(nil)
+	return &Tx{navigator: &navigator{source: fm.source, log: &undoLog{}, readOnly: fm.readOnly}, origin: fm, events: events}
+}
+
+// Commit keeps the changes made in the transaction
+func (tx *Tx) Commit() error {
+	if tx.done {
+		return ErrTxDone
+	}
+	tx.done = true
+	tx.origin.source = tx.source
+	tx.log.unbind(tx.navigator)
+	tx.origin.record(*tx.log)
+	tx.origin.endWrite(tx.events)
+	return nil
+}
+
+// Rollback reverts all changes made in the transaction in reverse order
+func (tx *Tx) Rollback() error {
+	if tx.done {
+		return ErrTxDone
+	}
+	tx.done = true
+	tx.log.rollback(tx.navigator)
+	tx.origin.endWrite(nil)
+	return nil
+}
@@ -0,0 +1,83 @@
+package delve
+
+import (
+	"slices"
+
+	"github.com/vloldik/delve/v3/internal/defaultval"
+	"github.com/vloldik/delve/v3/internal/quals"
+	"github.com/vloldik/delve/v3/internal/sources"
+	"github.com/vloldik/delve/v3/internal/value"
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
+
+// WalkAction tells Walk how to proceed after visiting a value.
+type WalkAction uint8
+
+const (
+	// WalkContinue descends into the value if it is a container and continues the walk
+	WalkContinue WalkAction = iota
+	// WalkSkip continues the walk without descending into the value
+	WalkSkip
+	// WalkStop stops the walk
+	WalkStop
+)
+
+// WalkFunc is called by Walk for every visited value with the concrete path to it.
+type WalkFunc func(path idelve.IQual, v *value.Value) WalkAction
+
+// WalkOptions configures Navigator.Walk. The zero value visits keys in the order of the sources.
+type WalkOptions struct {
+	// Sorted visits map keys in ascending (string) order, so the walk is deterministic.
+	// Lists are always visited in index order.
+	Sorted bool
+}
+
+// Walk visits every value of the navigator depth-first: containers (maps, lists, Go structs
+// and custom sources implementing Range) are visited before their children, the root itself
+// is not visited. Custom sources which don't implement Range are visited as leaves.
+// Children of a container are collected under the read lock of a synchronized navigator and
+// visit is called after it is released, so visit may read and change the navigator. Values
+// added or removed by visit in containers which haven't been collected yet may or may not be visited.
+//
+// Example:
+//
+//	nav.Walk(func(path idelve.IQual, v *delve.Value) delve.WalkAction {
+//	    if _, secret := v.SafeInterface(nil).(Secret); secret {
+//	        return delve.WalkSkip
+//	    }
+//	    fmt.Println(path, v.Interface())
+//	    return delve.WalkContinue
+//	}, delve.WalkOptions{Sorted: true})
+func (fm *navigator) Walk(visit WalkFunc, _opts ...WalkOptions) {
+	fm.readLock()
+	source := fm.source
+	fm.readUnlock()
+	if source == nil {
+		return
+	}
+	fm.walk(source, nil, visit, defaultval.WithDefaultEmpty(_opts))
+}
+
+// lockedItems collects keys and values of source under the read lock, see sources.Items
+func (fm *navigator) lockedItems(source idelve.ISource, sorted bool) []sources.Item {
+	fm.readLock()
+	defer fm.readUnlock()
+	return sources.Items(source, sorted)
+}
+
+// walk visits children of source. Returns false if the walk was stopped.
+func (fm *navigator) walk(source idelve.ISource, path []string, visit WalkFunc, opts WalkOptions) bool {
+	for _, item := range fm.lockedItems(source, opts.Sorted) {
+		path := append(path, item.Key)
+		switch visit(quals.FromParts(slices.Clone(path)), value.New(item.Val)) {
+		case WalkStop:
+			return false
+		case WalkSkip:
+			continue
+		}
+		if inner := sources.GetSource(item.Val); inner != nil && !fm.walk(inner, path, visit, opts) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,165 @@
+package delve
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/vloldik/delve/v3/internal/quals"
+	"github.com/vloldik/delve/v3/internal/sources"
+	"github.com/vloldik/delve/v3/internal/value"
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
+
+// WatchFunc is called with the value of a watched path before and after a change.
+// Nil-value containers are passed for missing values.
+type WatchFunc func(old, new *value.Value)
+
+// watcher is a single subscription of Watch
+type watcher struct {
+	path     []string
+	callback WatchFunc
+}
+
+// watchRegistry holds subscriptions of a navigator and is shared by its sub-navigators
+type watchRegistry struct {
+	// root is the navigator paths of watchers are relative to
+	root     *navigator
+	watchers []*watcher
+}
+
+// QWatch calls callback after every change of the value at qual made through the navigator,
+// its sub-navigators obtained after the call or transactions: QSet, QDelete, patches, merges and
+// source replacements. Watching a path also reports changes of nested values (watching "db"
+// fires for "db.dsn") and of its parents. Changes made in a transaction are reported on Commit.
+//
+// The callback receives a deep copy of the value before the change and the current value;
+// it is not called if they are equal. Callbacks of a single change are called in the order of
+// subscription after the change is complete (and a synchronized navigator is unlocked).
+// Every watched value is copied before each change affecting it, so watch narrow paths.
+// Returns a function removing the subscription.
+//
+// Example:
+//
+//	unwatch := config.QWatch(delve.CQ("db.dsn"), func(old, new *delve.Value) {
+//	    client.Reconnect(new.String())
+//	})
+//	defer unwatch()
+func (fm *navigator) QWatch(qual idelve.IQual, callback WatchFunc) func() {
+	fm.writeLock()
+	defer fm.writeUnlock()
+	if fm.watchers == nil {
+		fm.watchers = &watchRegistry{root: fm}
+	}
+	registry := fm.watchers
+	w := &watcher{path: fm.absolutePath(qualParts(qual)), callback: callback}
+	registry.watchers = append(slices.Clip(registry.watchers), w)
+
+	return func() {
+		fm.writeLock()
+		defer fm.writeUnlock()
+		registry.watchers = slices.DeleteFunc(slices.Clone(registry.watchers), func(other *watcher) bool {
+			return other == w
+		})
+	}
+}
+
+// Watch is like QWatch for a string-qualified path. Default path delimiter is '.'.
+func (fm *navigator) Watch(qual string, callback WatchFunc, _delimiter ...rune) func() {
+	return fm.QWatch(quals.Q(qual, _delimiter...), callback)
+}
+
+// absolutePath converts a path relative to fm to a path relative to the watch registry root
+func (fm *navigator) absolutePath(path []string) []string {
+	if len(fm.prefix) == 0 {
+		return path
+	}
+	return append(slices.Clone(fm.prefix), path...)
+}
+
+// watchEvent is a pending notification of a single watcher
+type watchEvent struct {
+	watcher  *watcher
+	old, new any
+	changed  bool
+}
+
+// watchEvents collects notifications of a single change
+type watchEvents struct {
+	registry *watchRegistry
+	events   []watchEvent
+}
+
+// beginWrite takes the write lock and remembers values of watchers affected by a change
+// of qual (of any path if qual is nil). Returns nil if there is nothing to watch.
+func (fm *navigator) beginWrite(qual idelve.IQual) *watchEvents {
+	fm.writeLock()
+	if fm.watchers == nil || len(fm.watchers.watchers) == 0 {
+		return nil
+	}
+	var changed []string
+	if qual != nil {
+		changed = fm.absolutePath(qualParts(qual))
+	}
+	events := &watchEvents{registry: fm.watchers}
+	for _, w := range fm.watchers.watchers {
+		if qual == nil || pathsOverlap(w.path, changed) {
+			events.events = append(events.events, watchEvent{watcher: w, old: events.current(w)})
+		}
+	}
+	return events
+}
+
+// endWrite checks which watched values have changed, releases the write lock and notifies watchers
+func (fm *navigator) endWrite(events *watchEvents) {
+	events.collect()
+	fm.writeUnlock()
+	events.notify()
+}
+
+// current returns a copy of the watched value
+func (e *watchEvents) current(w *watcher) any {
+	val, _ := e.registry.root.getAt(w.path)
+	return value.DeepCopy(val)
+}
+
+func (e *watchEvents) collect() {
+	if e == nil {
+		return
+	}
+	for i := range e.events {
+		event := &e.events[i]
+		event.new, _ = e.registry.root.getAt(event.watcher.path)
+		event.changed = !reflect.DeepEqual(event.old, event.new)
+	}
+}
+
+func (e *watchEvents) notify() {
+	if e == nil {
+		return
+	}
+	for _, event := range e.events {
+		if event.changed {
+			event.watcher.callback(value.New(event.old), value.New(event.new))
+		}
+	}
+}
+
+// pathsOverlap reports whether a change of one path may change the other one: one of them
+// is a prefix of the other or they differ in list indices, which may be shifted by the change.
+func pathsOverlap(a, b []string) bool {
+	for i := 0; i < min(len(a), len(b)); i++ {
+		if a[i] != b[i] {
+			return isIndex(a[i]) && isIndex(b[i])
+		}
+	}
+	return true
+}
+
+func isIndex(part string) bool {
+	if sources.IsAppend(part) {
+		return true
+	}
+	_, err := strconv.Atoi(part)
+	return err == nil
+}
@@ -13,8 +13,9 @@
 package delve
 
 import (
-	"fmt"
+	"sync"
 
+	"github.com/vloldik/delve/v3/internal/defaultval"
 	"github.com/vloldik/delve/v3/internal/quals"
 	"github.com/vloldik/delve/v3/internal/sources"
 	"github.com/vloldik/delve/v3/internal/value"
@@ -48,26 +49,61 @@ func From(source idelve.ISource) Navigator {
 // Use the exported Navigator type alias instead of direct references.
 type navigator struct {
 	source idelve.ISource
+	// lock is shared by a synchronized navigator and its sub-navigators, nil otherwise
+	lock *sync.RWMutex
+	// log records changes made inside a transaction to revert them, nil otherwise
+	log *undoLog
+	// watchers holds subscriptions of Watch, nil if there are none
+	watchers *watchRegistry
+	// prefix is the path of a sub-navigator relative to the root of watchers
+	prefix []string
+	// readOnly is set for views returned by ReadOnly and their sub-navigators
+	readOnly bool
 }
 
 // Source returns the underlying ISource implementation.
 // Useful for accessing low-level data source features not exposed by the Navigator interface.
+// Access through the source is not synchronized.
 func (fm *navigator) Source() idelve.ISource {
+	fm.readLock()
+	defer fm.readUnlock()
 	return fm.source
 }
 
 // QGetRaw retrieves a raw value from the data source using a qualified path.
 // Returns the value and an existence flag. Prefer QGet for type-wrapped values.
 func (fm *navigator) QGetRaw(qual idelve.IQual) (any, bool) {
-	return fm.qualGet(qual)
+	return fm.lockedGet(qual)
 }
 
 // QSet updates the data source at the specified qualified path with the given value.
 // Returns true if the operation succeeded. Fails if the path doesn't exist or is read-only.
 func (fm *navigator) QSet(qual idelve.IQual, value any) bool {
+	if fm.readOnly {
+		return false
+	}
+	defer fm.endWrite(fm.beginWrite(qual))
 	return fm.qualSet(qual, value)
 }
 
+// QDelete removes the value at the specified qualified path.
+// The container holding the last path segment must implement idelve.IDeleter,
+// list elements after the removed one are shifted left.
+// Returns false if the path doesn't exist or the container doesn't support deletion.
+func (fm *navigator) QDelete(qual idelve.IQual) bool {
+	if fm.readOnly {
+		return false
+	}
+	defer fm.endWrite(fm.beginWrite(qual))
+	return fm.qualDelete(qual)
+}
+
+// Delete removes the value at the specified string-qualified path.
+// Default path delimiter is '.'. See QDelete for details.
+func (fm *navigator) Delete(qual string, _delimiter ...rune) bool {
+	return fm.QDelete(quals.Q(qual, _delimiter...))
+}
+
 // Get retrieves a value using a string-qualified path with optional delimiter customization.
 // Default path delimiter is '.'. Returns a value.Value wrapper for type-safe operations.
 func (fm *navigator) Get(qual string, _delimiter ...rune) *value.Value {
@@ -84,19 +120,163 @@ func (fm *navigator) Set(qual string, value any, _delimiter ...rune) bool {
 // QGet retrieves a qualified path value wrapped in a value.Value container.
 // Returns nil-value container if path doesn't exist.
 func (fm *navigator) QGet(qual idelve.IQual) *value.Value {
-	v, _ := fm.qualGet(qual)
+	v, _ := fm.lockedGet(qual)
 	return value.New(v)
 }
 
+// QGetE retrieves a qualified path value wrapped in a value.Value container.
+// Unlike QGet, it returns a *PathError describing the failing segment if the path can't be resolved.
+func (fm *navigator) QGetE(qual idelve.IQual) (*value.Value, error) {
+	fm.readLock()
+	defer fm.readUnlock()
+	v, failure := fm.qualResolve(qual)
+	if failure.failed() {
+		return nil, failure.toError(qual)
+	}
+	return value.New(v), nil
+}
+
+// GetE retrieves a value using a string-qualified path with optional delimiter customization.
+// Returns a *PathError if the path can't be resolved. See QGetE.
+func (fm *navigator) GetE(qual string, _delimiter ...rune) (*value.Value, error) {
+	return fm.QGetE(quals.Q(qual, _delimiter...))
+}
+
+// QSetE updates the data source at the specified qualified path with the given value.
+// Unlike QSet, it returns a *PathError describing the failing segment instead of false.
+func (fm *navigator) QSetE(qual idelve.IQual, value any) error {
+	if fm.readOnly {
+		return readOnlyError(qual)
+	}
+	defer fm.endWrite(fm.beginWrite(qual))
+	return fm.qualSetE(qual, value).toError(qual)
+}
+
+// SetE updates the data source at the specified string-qualified path with the given value.
+// Returns a *PathError if the value can't be set. See QSetE.
+func (fm *navigator) SetE(qual string, value any, _delimiter ...rune) error {
+	return fm.QSetE(quals.Q(qual, _delimiter...), value)
+}
+
+// QGetAll retrieves every value matching a qualifier which may contain wildcard
+// segments ("*" matches any map key or list index, "\\*" is a literal "*" key)
+// or recursive descent segments ("**" matches zero or more levels, so "**.error.code"
+// finds every "error.code" at any depth). A trailing "**" matches every nested value.
+// Several overlapping recursive segments may report the same path more than once.
+// Each match carries the concrete path to the value. Order of matches within a map is not specified.
+// Wildcards can't enumerate custom sources which don't implement Range.
+func (fm *navigator) QGetAll(qual idelve.IQual) []Match {
+	fm.readLock()
+	defer fm.readUnlock()
+	return fm.qualGetAll(qual)
+}
+
+// GetAll retrieves every value matching a string-qualified path. See QGetAll.
+func (fm *navigator) GetAll(qual string, _delimiter ...rune) []Match {
+	return fm.QGetAll(quals.Q(qual, _delimiter...))
+}
+
+// Query evaluates a compiled JSONPath query against the navigator data and returns
+// every selected value with its concrete path. Works with custom sources as well,
+// wildcards and filters require them to implement Range.
+func (fm *navigator) Query(query *Query) []Match {
+	fm.readLock()
+	defer fm.readUnlock()
+	if fm.source == nil {
+		return nil
+	}
+	nodes := query.Evaluate(fm.source)
+	matches := make([]Match, len(nodes))
+	for i, node := range nodes {
+		matches[i] = Match{Path: quals.FromParts(node.Path), Value: value.New(node.Value)}
+	}
+	return matches
+}
+
+// ApplyPatch applies an RFC 6902 JSON Patch (add, remove, replace, move, copy and test operations).
+// The patch is atomic: if any operation fails, all previous operations are reverted and
+// a *PatchError with the index of the failed operation is returned.
+//
+// Example:
+//
+//	var ops []delve.PatchOperation
+//	_ = json.Unmarshal(body, &ops)
+//	if err := navigator.ApplyPatch(ops); err != nil { ... }
+func (fm *navigator) ApplyPatch(ops []PatchOperation) error {
+	if fm.readOnly && len(ops) > 0 {
+		qual, err := quals.ParsePointer(ops[0].Path)
+		if err == nil {
+			err = readOnlyError(qual)
+		}
+		return &PatchError{Index: 0, Op: ops[0], Err: err}
+	}
+	defer fm.endWrite(fm.beginWrite(nil))
+	return fm.applyPatch(ops)
+}
+
+// MergePatch applies an RFC 7386 JSON Merge Patch: maps are merged recursively,
+// nil values delete keys and any other value (including lists) replaces the existing one.
+// If the patch can't be applied (e.g. a read-only source), the data is left untouched.
+func (fm *navigator) MergePatch(patch map[string]any) error {
+	if fm.readOnly {
+		return readOnlyError(quals.FromParts(nil))
+	}
+	defer fm.endWrite(fm.beginWrite(nil))
+	return fm.mergePatch(patch)
+}
+
+// Merge deep merges data of other navigator into this one. Maps are merged recursively,
+// lists and conflicting values are combined according to options (the zero MergeOptions
+// replaces lists and overwrites conflicting values). Merged values are copied, so the
+// navigators don't share maps and lists afterwards. If merge fails (e.g. ConflictError strategy),
+// the data is left untouched. Data of a synchronized other navigator is copied under its read lock first.
+//
+// Example:
+//
+//	err := base.Merge(override, delve.MergeOptions{Lists: delve.ListMergeByKey, ListKey: "name"})
+func (fm *navigator) Merge(other Navigator, _opts ...MergeOptions) error {
+	if fm.readOnly {
+		return readOnlyError(quals.FromParts(nil))
+	}
+	incoming := other.lockedRoot()
+	defer fm.endWrite(fm.beginWrite(nil))
+	return fm.merge(incoming, defaultval.WithDefaultEmpty(_opts))
+}
+
+// Clone returns a navigator over a deep copy of the data, so changes of either navigator
+// are not visible through the other one. Maps, slices (including typed ones), structs and
+// pointers are copied and numbers keep their exact types. Custom sources are copied only if
+// they implement idelve.ICloner, otherwise they are shared. A clone of a synchronized
+// navigator is synchronized by its own lock. Clones of read-only views and of struct values are writable.
+func (fm *navigator) Clone() Navigator {
+	fm.readLock()
+	defer fm.readUnlock()
+	clone := &navigator{}
+	if fm.source != nil {
+		clone.source = sources.GetAddressableSource(value.DeepCopy(sources.Unwrap(fm.source)))
+	}
+	if fm.lock != nil {
+		clone.lock = &sync.RWMutex{}
+	}
+	return clone
+}
+
 // QGetNavigator retrieves a sub-navigator for a qualified path.
 // Useful for chaining operations on nested structures. Returns nil for nonexistent paths.
+// Sub-navigators of a synchronized navigator share its lock, sub-navigators of a read-only view are read-only.
 func (fm *navigator) QGetNavigator(qual idelve.IQual) Navigator {
+	fm.readLock()
+	defer fm.readUnlock()
 	v, ok := fm.qualGet(qual)
 	if !ok {
 		return nil
 	}
 	if source := sources.GetSource(v); source != nil {
-		return &navigator{source: source}
+		sub := &navigator{source: source, lock: fm.lock, log: fm.log, watchers: fm.watchers, readOnly: fm.readOnly}
+		if fm.watchers != nil {
+			sub.prefix = fm.absolutePath(qualParts(qual))
+		}
+		return sub
 	} else {
 		return nil
 	}
@@ -109,12 +289,16 @@ func (fm *navigator) GetNavigator(qual string, _delimiter ...rune) Navigator {
 }
 
 // QMust retrieves a raw value with panic on missing path.
+// The panic value is a *PathError describing the failing segment.
 // Use for mandatory value retrieval. Prefer QGet with existence checks for safer access.
 func (fm *navigator) QMust(qual idelve.IQual) any {
-	if val, ok := fm.QGetRaw(qual); ok {
-		return val
+	fm.readLock()
+	defer fm.readUnlock()
+	val, failure := fm.qualResolve(qual)
+	if failure.failed() {
+		panic(failure.toError(qual))
 	}
-	panic(fmt.Sprintf("could not get by qual %v", qual))
+	return val
 }
 
 // SetMapSource replaces the underlying data source with a new map.
@@ -131,6 +315,13 @@ func (fm *navigator) SetListSource(source []any) {
 
 // SetSource replaces the underlying ISource implementation.
 // Allows switching between different data source types while preserving navigation logic.
+// Does nothing for a read-only view.
 func (fm *navigator) SetSource(source idelve.ISource) {
-	fm.source = source
+	if fm.readOnly {
+		return
+	}
+	defer fm.endWrite(fm.beginWrite(nil))
+	var changes undoLog
+	fm.replaceSource(source, &changes)
+	fm.record(changes)
 }
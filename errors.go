@@ -0,0 +1,102 @@
+package delve
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/vloldik/delve/v3/internal/sources"
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
+
+// PathErrorReason describes why a qualified path could not be resolved or updated.
+type PathErrorReason uint8
+
+const (
+	// NotFound means the key is missing in a map or custom source.
+	NotFound PathErrorReason = iota + 1
+	// IndexOutOfRange means the segment is a valid list index, but the list is too short.
+	IndexOutOfRange
+	// InvalidIndex means the segment addresses a list, but is not an integer (or "+" for set).
+	InvalidIndex
+	// NotContainer means the previous segment resolved to a value which can't be navigated (e.g. a scalar).
+	NotContainer
+	// ReadOnly means the source refused to store a value.
+	ReadOnly
+)
+
+func (r PathErrorReason) String() string {
+	switch r {
+	case NotFound:
+		return "not found"
+	case IndexOutOfRange:
+		return "index out of range"
+	case InvalidIndex:
+		return "invalid index"
+	case NotContainer:
+		return "not a container"
+	case ReadOnly:
+		return "read only"
+	default:
+		return "unknown reason " + strconv.Itoa(int(r))
+	}
+}
+
+// PathError is returned by the error-reporting variants of Navigator methods
+// (QGetE, QSetE, ...) and describes which segment of the qualifier failed and why.
+type PathError struct {
+	// Qual is the qualifier which failed
+	Qual idelve.IQual
+	// Index is the zero-based index of the failing segment
+	Index int
+	// Segment is the text of the failing segment
+	Segment string
+	// Reason describes the failure
+	Reason PathErrorReason
+}
+
+func (e *PathError) Error() string {
+	return fmt.Sprintf("delve: %v at segment %d (%q) of qual %v", e.Reason, e.Index, e.Segment, e.Qual)
+}
+
+// pathFailure is an allocation-free description of a traversal failure.
+// The zero value means the traversal succeeded.
+type pathFailure struct {
+	reason  PathErrorReason
+	index   int
+	segment string
+}
+
+func (f pathFailure) failed() bool {
+	return f.reason != 0
+}
+
+// toError converts failure to a *PathError or returns nil if there was no failure
+func (f pathFailure) toError(qual idelve.IQual) error {
+	if !f.failed() {
+		return nil
+	}
+	return &PathError{Qual: qual, Index: f.index, Segment: f.segment, Reason: f.reason}
+}
+
+// getFailure describes why segment could not be read from source
+func getFailure(source idelve.ISource, index int, segment string) pathFailure {
+	if sources.IsList(source) {
+		return pathFailure{reason: indexReason(segment), index: index, segment: segment}
+	}
+	return pathFailure{reason: NotFound, index: index, segment: segment}
+}
+
+// setFailure describes why segment could not be written to source
+func setFailure(source idelve.ISource, index int, segment string) pathFailure {
+	if sources.IsList(source) {
+		return pathFailure{reason: indexReason(segment), index: index, segment: segment}
+	}
+	return pathFailure{reason: ReadOnly, index: index, segment: segment}
+}
+
+func indexReason(segment string) PathErrorReason {
+	if _, err := strconv.Atoi(segment); err != nil {
+		return InvalidIndex
+	}
+	return IndexOutOfRange
+}
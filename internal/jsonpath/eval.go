@@ -0,0 +1,171 @@
+package jsonpath
+
+import (
+	"strconv"
+
+	"github.com/vloldik/delve/v3/internal/sources"
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
+
+// Node is a single value selected by a query
+type Node struct {
+	// Path is the list of keys (list indices formatted as decimal) leading to the value
+	Path []string
+	// Value is the selected value
+	Value any
+}
+
+// lener is implemented by list sources which know their length
+type lener interface {
+	Len() int
+}
+
+// Evaluate applies the query to root and returns the selected nodes.
+// Root may be any value accepted by sources.GetSource, including an idelve.ISource.
+func (q *Query) Evaluate(root any) []Node {
+	return q.evaluate(root, root)
+}
+
+func (q *Query) evaluate(root, start any) []Node {
+	nodes := []Node{{Value: start}}
+	for _, seg := range q.segments {
+		var next []Node
+		for _, node := range nodes {
+			if seg.descendant {
+				next = descend(node, seg.selectors, root, next)
+			} else {
+				next = applySelectors(node, seg.selectors, root, next)
+			}
+		}
+		if nodes = next; len(nodes) == 0 {
+			break
+		}
+	}
+	return nodes
+}
+
+// descend applies selectors to node and all of its descendants
+func descend(node Node, selectors []selector, root any, out []Node) []Node {
+	out = applySelectors(node, selectors, root, out)
+	source := sources.GetSource(node.Value)
+	if r, ok := source.(sources.Ranger); ok {
+		r.Range(func(key string, val any) bool {
+			if sources.GetSource(val) != nil {
+				out = descend(child(node, key, val), selectors, root, out)
+			}
+			return true
+		})
+	}
+	return out
+}
+
+func applySelectors(node Node, selectors []selector, root any, out []Node) []Node {
+	source := sources.GetSource(node.Value)
+	if source == nil {
+		return out
+	}
+	for i := range selectors {
+		out = applySelector(node, source, &selectors[i], root, out)
+	}
+	return out
+}
+
+func applySelector(node Node, source idelve.ISource, sel *selector, root any, out []Node) []Node {
+	switch sel.kind {
+	case nameSelector:
+		if sources.IsList(source) {
+			return out
+		}
+		if val, ok := source.Get(sel.name); ok {
+			out = append(out, child(node, sel.name, val))
+		}
+	case wildcardSelector:
+		if r, ok := source.(sources.Ranger); ok {
+			r.Range(func(key string, val any) bool {
+				out = append(out, child(node, key, val))
+				return true
+			})
+		}
+	case indexSelector:
+		out = appendIndex(node, source, sel.index, out)
+	case sliceSelector:
+		list, ok := source.(lener)
+		if !ok {
+			return out
+		}
+		for _, index := range sliceIndices(sel.slice, list.Len()) {
+			out = appendIndex(node, source, index, out)
+		}
+	case filterSelector:
+		if r, ok := source.(sources.Ranger); ok {
+			r.Range(func(key string, val any) bool {
+				if sel.filter.test(root, val) {
+					out = append(out, child(node, key, val))
+				}
+				return true
+			})
+		}
+	}
+	return out
+}
+
+// appendIndex appends list element at index (negative indices are counted from the end)
+func appendIndex(node Node, source idelve.ISource, index int, out []Node) []Node {
+	if !sources.IsList(source) {
+		return out
+	}
+	if list, ok := source.(lener); ok && index < 0 {
+		index += list.Len()
+	}
+	key := strconv.Itoa(index)
+	if val, ok := source.Get(key); ok {
+		out = append(out, child(node, key, val))
+	}
+	return out
+}
+
+// sliceIndices returns indices selected by slice bounds in a list of length n
+func sliceIndices(bounds sliceBounds, n int) []int {
+	step := bounds.step
+	if step == 0 {
+		return nil
+	}
+	normalize := func(i int) int {
+		if i < 0 {
+			return i + n
+		}
+		return i
+	}
+	var indices []int
+	if step > 0 {
+		start, end := 0, n
+		if bounds.hasStart {
+			start = min(max(normalize(bounds.start), 0), n)
+		}
+		if bounds.hasEnd {
+			end = min(max(normalize(bounds.end), 0), n)
+		}
+		for i := start; i < end; i += step {
+			indices = append(indices, i)
+		}
+		return indices
+	}
+	start, end := n-1, -1
+	if bounds.hasStart {
+		start = min(max(normalize(bounds.start), -1), n-1)
+	}
+	if bounds.hasEnd {
+		end = min(max(normalize(bounds.end), -1), n-1)
+	}
+	for i := start; i > end; i += step {
+		indices = append(indices, i)
+	}
+	return indices
+}
+
+func child(parent Node, key string, val any) Node {
+	path := make([]string, len(parent.Path)+1)
+	copy(path, parent.Path)
+	path[len(parent.Path)] = key
+	return Node{Path: path, Value: val}
+}
@@ -0,0 +1,255 @@
+package jsonpath
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// boolExpr is a logical expression of a filter selector
+type boolExpr interface {
+	test(root, current any) bool
+}
+
+// operand is a value side of a comparison. Returns false if the value doesn't exist.
+type operand interface {
+	value(root, current any) (any, bool)
+}
+
+type orExpr struct{ left, right boolExpr }
+
+func (e orExpr) test(root, current any) bool {
+	return e.left.test(root, current) || e.right.test(root, current)
+}
+
+type andExpr struct{ left, right boolExpr }
+
+func (e andExpr) test(root, current any) bool {
+	return e.left.test(root, current) && e.right.test(root, current)
+}
+
+type notExpr struct{ inner boolExpr }
+
+func (e notExpr) test(root, current any) bool {
+	return !e.inner.test(root, current)
+}
+
+// existsExpr tests that a query selects at least one node
+type existsExpr struct{ query queryOperand }
+
+func (e existsExpr) test(root, current any) bool {
+	return len(e.query.nodes(root, current)) > 0
+}
+
+type compareExpr struct {
+	op          string
+	left, right operand
+}
+
+func (e compareExpr) test(root, current any) bool {
+	left, leftOk := e.left.value(root, current)
+	right, rightOk := e.right.value(root, current)
+	switch e.op {
+	case "==":
+		return equal(left, leftOk, right, rightOk)
+	case "!=":
+		return !equal(left, leftOk, right, rightOk)
+	case "<":
+		return less(left, leftOk, right, rightOk)
+	case ">":
+		return less(right, rightOk, left, leftOk)
+	case "<=":
+		return less(left, leftOk, right, rightOk) || equal(left, leftOk, right, rightOk)
+	case ">=":
+		return less(right, rightOk, left, leftOk) || equal(left, leftOk, right, rightOk)
+	}
+	return false
+}
+
+type literal struct{ val any }
+
+func (l literal) value(any, any) (any, bool) {
+	return l.val, true
+}
+
+// queryOperand is an embedded query relative to the root ($) or the current node (@)
+type queryOperand struct {
+	query    *Query
+	relative bool
+}
+
+func (q queryOperand) nodes(root, current any) []Node {
+	if q.relative {
+		return q.query.evaluate(root, current)
+	}
+	return q.query.evaluate(root, root)
+}
+
+// value returns the value of a query selecting exactly one node
+func (q queryOperand) value(root, current any) (any, bool) {
+	nodes := q.nodes(root, current)
+	if len(nodes) != 1 {
+		return nil, false
+	}
+	return nodes[0].Value, true
+}
+
+func equal(left any, leftOk bool, right any, rightOk bool) bool {
+	if !leftOk || !rightOk {
+		return leftOk == rightOk
+	}
+	if l, ok := toFloat(left); ok {
+		r, ok := toFloat(right)
+		return ok && l == r
+	}
+	return reflect.DeepEqual(left, right)
+}
+
+func less(left any, leftOk bool, right any, rightOk bool) bool {
+	if !leftOk || !rightOk {
+		return false
+	}
+	if l, ok := toFloat(left); ok {
+		r, ok := toFloat(right)
+		return ok && l < r
+	}
+	if l, ok := left.(string); ok {
+		r, ok := right.(string)
+		return ok && l < r
+	}
+	return false
+}
+
+// toFloat converts a value of any numeric kind to float64
+func toFloat(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+func (p *parser) parseOr() (boolExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if !p.consume("||") {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left, right}
+	}
+}
+
+func (p *parser) parseAnd() (boolExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if !p.consume("&&") {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left, right}
+	}
+}
+
+func (p *parser) parseUnary() (boolExpr, error) {
+	p.skipSpaces()
+	if p.peek() == '!' && !strings.HasPrefix(p.expr[p.pos:], "!=") {
+		p.pos++
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{inner}, nil
+	}
+	if p.consume("(") {
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if !p.consume(")") {
+			return nil, p.errorf("expected ')'")
+		}
+		return inner, nil
+	}
+	return p.parseComparison()
+}
+
+var comparisonOps = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+func (p *parser) parseComparison() (boolExpr, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	for _, op := range comparisonOps {
+		if p.consume(op) {
+			p.skipSpaces()
+			right, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			return compareExpr{op: op, left: left, right: right}, nil
+		}
+	}
+	if query, ok := left.(queryOperand); ok {
+		return existsExpr{query}, nil
+	}
+	return nil, p.errorf("expected comparison")
+}
+
+func (p *parser) parseOperand() (operand, error) {
+	switch c := p.peek(); {
+	case c == '@' || c == '$':
+		query, err := p.parseQuery(c)
+		if err != nil {
+			return nil, err
+		}
+		return queryOperand{query: query, relative: c == '@'}, nil
+	case c == '\'' || c == '"':
+		s, err := p.parseString()
+		return literal{s}, err
+	case c == '-' || isDigit(c):
+		return p.parseNumber()
+	case p.consume("true"):
+		return literal{true}, nil
+	case p.consume("false"):
+		return literal{false}, nil
+	case p.consume("null"):
+		return literal{nil}, nil
+	}
+	return nil, p.errorf("expected operand")
+}
+
+func (p *parser) parseNumber() (operand, error) {
+	start := p.pos
+	for p.pos < len(p.expr) && strings.IndexByte("+-.eE0123456789", p.expr[p.pos]) >= 0 {
+		p.pos++
+	}
+	n, err := strconv.ParseFloat(p.expr[start:p.pos], 64)
+	if err != nil {
+		p.pos = start
+		return nil, p.errorf("invalid number")
+	}
+	return literal{n}, nil
+}
@@ -0,0 +1,324 @@
+package jsonpath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type selectorKind uint8
+
+const (
+	nameSelector selectorKind = iota
+	wildcardSelector
+	indexSelector
+	sliceSelector
+	filterSelector
+)
+
+type selector struct {
+	kind   selectorKind
+	name   string
+	index  int
+	slice  sliceBounds
+	filter boolExpr
+}
+
+type sliceBounds struct {
+	start, end       int
+	hasStart, hasEnd bool
+	step             int
+}
+
+type segment struct {
+	descendant bool
+	selectors  []selector
+}
+
+// Query is a compiled JSONPath expression
+type Query struct {
+	expr     string
+	segments []segment
+}
+
+func (q *Query) String() string {
+	return q.expr
+}
+
+// Compile parses a JSONPath expression.
+//
+// Supported syntax: root ($), child (.name, ['name'], ["name"]), wildcards (.*, [*]),
+// indices ([0], [-1]), slices ([start:end:step]), unions ([0,'a']), recursive descent (..name, ..*, ..[0])
+// and filters ([?(@.price < 10 && !@.sold)]) with ==, !=, <, <=, >, >=, &&, || and !.
+func Compile(expr string) (*Query, error) {
+	p := &parser{expr: expr}
+	query, err := p.parseQuery('$')
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.expr) {
+		return nil, p.errorf("unexpected %q", p.expr[p.pos:])
+	}
+	return query, nil
+}
+
+type parser struct {
+	expr string
+	pos  int
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("jsonpath: %s at position %d of %q", fmt.Sprintf(format, args...), p.pos, p.expr)
+}
+
+func (p *parser) peek() byte {
+	if p.pos >= len(p.expr) {
+		return 0
+	}
+	return p.expr[p.pos]
+}
+
+func (p *parser) skipSpaces() {
+	for p.pos < len(p.expr) && strings.IndexByte(" \t\n\r", p.expr[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// consume skips token if the input continues with it
+func (p *parser) consume(token string) bool {
+	if strings.HasPrefix(p.expr[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+// parseQuery parses identifier (root '$' or current '@') followed by segments
+func (p *parser) parseQuery(identifier byte) (*Query, error) {
+	start := p.pos
+	if p.peek() != identifier {
+		return nil, p.errorf("expected %q", identifier)
+	}
+	p.pos++
+	var segments []segment
+	for {
+		seg, ok, err := p.parseSegment()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		segments = append(segments, seg)
+	}
+	return &Query{expr: p.expr[start:p.pos], segments: segments}, nil
+}
+
+// parseSegment parses a single child or descendant segment. Returns false if there is no segment.
+func (p *parser) parseSegment() (segment, bool, error) {
+	switch {
+	case p.consume(".."):
+		sel, err := p.parseDotSelector(true)
+		if err != nil {
+			return segment{}, false, err
+		}
+		if sel == nil {
+			selectors, err := p.parseBracket()
+			return segment{descendant: true, selectors: selectors}, err == nil, err
+		}
+		return segment{descendant: true, selectors: []selector{*sel}}, true, nil
+	case p.consume("."):
+		sel, err := p.parseDotSelector(false)
+		if err != nil {
+			return segment{}, false, err
+		}
+		return segment{selectors: []selector{*sel}}, true, nil
+	case p.peek() == '[':
+		selectors, err := p.parseBracket()
+		return segment{selectors: selectors}, err == nil, err
+	}
+	return segment{}, false, nil
+}
+
+// parseDotSelector parses a selector after a dot: a wildcard or a member name.
+// If allowBracket is set and a bracket follows, nil selector is returned.
+func (p *parser) parseDotSelector(allowBracket bool) (*selector, error) {
+	if p.consume("*") {
+		return &selector{kind: wildcardSelector}, nil
+	}
+	if allowBracket && p.peek() == '[' {
+		return nil, nil
+	}
+	start := p.pos
+	for p.pos < len(p.expr) {
+		r, size := utf8.DecodeRuneInString(p.expr[p.pos:])
+		if !isNameRune(r) {
+			break
+		}
+		p.pos += size
+	}
+	if start == p.pos {
+		return nil, p.errorf("expected member name")
+	}
+	return &selector{kind: nameSelector, name: p.expr[start:p.pos]}, nil
+}
+
+func isNameRune(r rune) bool {
+	return r == '_' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r) || r >= utf8.RuneSelf && r != utf8.RuneError
+}
+
+// parseBracket parses a bracketed list of selectors
+func (p *parser) parseBracket() ([]selector, error) {
+	if !p.consume("[") {
+		return nil, p.errorf("expected '['")
+	}
+	var selectors []selector
+	for {
+		p.skipSpaces()
+		sel, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, sel)
+		p.skipSpaces()
+		if p.consume("]") {
+			return selectors, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected ',' or ']'")
+		}
+	}
+}
+
+func (p *parser) parseSelector() (selector, error) {
+	switch c := p.peek(); {
+	case c == '\'' || c == '"':
+		name, err := p.parseString()
+		return selector{kind: nameSelector, name: name}, err
+	case c == '*':
+		p.pos++
+		return selector{kind: wildcardSelector}, nil
+	case c == '?':
+		p.pos++
+		filter, err := p.parseOr()
+		return selector{kind: filterSelector, filter: filter}, err
+	case c == ':' || c == '-' || isDigit(c):
+		return p.parseIndexOrSlice()
+	}
+	return selector{}, p.errorf("invalid selector")
+}
+
+func (p *parser) parseIndexOrSlice() (selector, error) {
+	var bounds [3]int
+	var present [3]bool
+	part := 0
+	for {
+		p.skipSpaces()
+		if c := p.peek(); c == '-' || isDigit(c) {
+			n, err := p.parseInt()
+			if err != nil {
+				return selector{}, err
+			}
+			bounds[part], present[part] = n, true
+			p.skipSpaces()
+		}
+		if part == 2 || !p.consume(":") {
+			break
+		}
+		part++
+	}
+	if part == 0 {
+		if !present[0] {
+			return selector{}, p.errorf("expected index")
+		}
+		return selector{kind: indexSelector, index: bounds[0]}, nil
+	}
+	step := 1
+	if present[2] {
+		step = bounds[2]
+	}
+	return selector{kind: sliceSelector, slice: sliceBounds{
+		start: bounds[0], hasStart: present[0],
+		end: bounds[1], hasEnd: present[1],
+		step: step,
+	}}, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func (p *parser) parseInt() (int, error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	for isDigit(p.peek()) {
+		p.pos++
+	}
+	n, err := strconv.Atoi(p.expr[start:p.pos])
+	if err != nil {
+		p.pos = start
+		return 0, p.errorf("invalid integer")
+	}
+	return n, nil
+}
+
+// parseString parses a single or double quoted string literal with JSON-like escapes
+func (p *parser) parseString() (string, error) {
+	quote := p.peek()
+	p.pos++
+	var builder strings.Builder
+	for p.pos < len(p.expr) {
+		c := p.expr[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return builder.String(), nil
+		case c == '\\':
+			p.pos++
+			if err := p.parseEscape(&builder); err != nil {
+				return "", err
+			}
+		default:
+			builder.WriteByte(c)
+			p.pos++
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *parser) parseEscape(builder *strings.Builder) error {
+	c := p.peek()
+	p.pos++
+	switch c {
+	case 'b':
+		builder.WriteByte('\b')
+	case 'f':
+		builder.WriteByte('\f')
+	case 'n':
+		builder.WriteByte('\n')
+	case 'r':
+		builder.WriteByte('\r')
+	case 't':
+		builder.WriteByte('\t')
+	case 'u':
+		if p.pos+4 > len(p.expr) {
+			return p.errorf("invalid unicode escape")
+		}
+		code, err := strconv.ParseUint(p.expr[p.pos:p.pos+4], 16, 32)
+		if err != nil {
+			return p.errorf("invalid unicode escape")
+		}
+		builder.WriteRune(rune(code))
+		p.pos += 4
+	case '\\', '/', '\'', '"':
+		builder.WriteByte(c)
+	default:
+		p.pos--
+		return p.errorf("invalid escape")
+	}
+	return nil
+}
@@ -1,4 +1,9 @@
-package value
+package numeric
+
+import (
+	"math"
+	"reflect"
+)
 
 // Numeric is a type constraint that includes all the common numeric types in Go.
 // The '~' before each type means that it includes any type whose *underlying* type is that type.
@@ -57,3 +62,58 @@ func AnyToNumeric[T Numeric](num any) (val T, ok bool) {
 	}
 	return // Return the zero value of type T and ok=false.  This is the zero value of T and `false`.
 }
+
+// TruncateNumeric converts an `any` number to the numeric type T truncating the fractional part
+// for integer types (e.g. float64(1.5) to int 1) and the precision for float32, so unlike AnyToNumeric
+// it accepts some lossy conversions. Returns false if num is not a number or is out of the range
+// of T (including negative numbers for unsigned types and NaN for integer types).
+func TruncateNumeric[T Numeric](num any) (T, bool) {
+	rv := reflect.ValueOf(num)
+	t := reflect.TypeFor[T]()
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			f = float64(rv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			f = float64(rv.Uint())
+		case reflect.Float32, reflect.Float64:
+			f = rv.Float()
+		default:
+			return 0, false
+		}
+		if t.Kind() == reflect.Float32 && math.Abs(f) > math.MaxFloat32 && !math.IsInf(f, 0) {
+			return 0, false
+		}
+		return T(f), true
+	}
+
+	bits := t.Bits()
+	signed := t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := rv.Int()
+		if signed && (i < -1<<(bits-1) || i > 1<<(bits-1)-1) || !signed && (i < 0 || bits < 64 && uint64(i) >= 1<<bits) {
+			return 0, false
+		}
+		return T(i), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := rv.Uint()
+		if signed && u > 1<<(bits-1)-1 || !signed && bits < 64 && u >= 1<<bits {
+			return 0, false
+		}
+		return T(u), true
+	case reflect.Float32, reflect.Float64:
+		f := math.Trunc(rv.Float())
+		limit := math.Ldexp(1, bits) // exclusive upper bound of unsigned types
+		if signed {
+			limit = math.Ldexp(1, bits-1)
+		}
+		if math.IsNaN(f) || f >= limit || signed && f < -limit || !signed && f < 0 {
+			return 0, false
+		}
+		return T(f), true
+	}
+	return 0, false
+}
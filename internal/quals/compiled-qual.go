@@ -11,6 +11,7 @@ const DefaultDelimiter = '.' // Qdelimiter is used to separate nested keys in qu
 
 type compiledQual struct {
 	parts     []string
+	kinds     []idelve.SegmentKind // nil if all parts are plain keys
 	len       uint8
 	index     uint8
 	delimiter rune
@@ -20,6 +21,7 @@ func (c *compiledQual) Copy() idelve.IQual {
 	return &compiledQual{
 		// No need to copy list, it's read-only
 		parts:     c.parts,
+		kinds:     c.kinds,
 		len:       c.len,
 		index:     c.index,
 		delimiter: c.delimiter,
@@ -36,6 +38,27 @@ func (c *compiledQual) Next() (string, bool) {
 	return part, hasNext
 }
 
+func (c *compiledQual) NextSegment() (string, idelve.SegmentKind, bool) {
+	kind := idelve.KeySegment
+	if c.kinds != nil && c.index < c.len {
+		kind = c.kinds[c.index]
+	}
+	part, hasNext := c.Next()
+	return part, kind, hasNext
+}
+
+// SegmentAt returns the part with index pos without changing the qualifier state
+func (c *compiledQual) SegmentAt(pos int) (string, idelve.SegmentKind, int, bool) {
+	if pos >= int(c.len) {
+		return "", idelve.KeySegment, pos + 1, false
+	}
+	kind := idelve.KeySegment
+	if c.kinds != nil {
+		kind = c.kinds[pos]
+	}
+	return c.parts[pos], kind, pos + 1, pos+1 < int(c.len)
+}
+
 func (c *compiledQual) Reset() {
 	c.index = 0
 }
@@ -62,6 +85,9 @@ func (c *compiledQual) String() string {
 		if i > 0 {
 			builder.WriteByte(byte(DefaultDelimiter))
 		}
+		if segmentKind(part) != idelve.KeySegment && (c.kinds == nil || c.kinds[i] == idelve.KeySegment) {
+			builder.WriteRune('\\')
+		}
 		for _, r := range part {
 			if r == DefaultDelimiter || r == '\\' {
 				builder.WriteRune('\\')
@@ -82,9 +108,26 @@ func CQ(qual string, _delimiter ...rune) *compiledQual {
 	expectedParts := strings.Count(qual, string(delimiter)) + 1
 	parts := make([]string, 0, expectedParts)
 
+	var kinds []idelve.SegmentKind
+
 	var currentPart strings.Builder
 	currentPart.Grow(16) // Preallocate a small buffer to minimize reallocations
-	var escapeNext bool
+	var escapeNext, escaped bool
+
+	appendPart := func() {
+		part := currentPart.String()
+		if kind := segmentKind(part); !escaped && kind != idelve.KeySegment {
+			if kinds == nil {
+				kinds = make([]idelve.SegmentKind, len(parts), cap(parts))
+			}
+			kinds = append(kinds, kind)
+		} else if kinds != nil {
+			kinds = append(kinds, idelve.KeySegment)
+		}
+		parts = append(parts, part)
+		currentPart.Reset()
+		escaped = false
+	}
 
 	for _, r := range qual {
 		if escapeNext {
@@ -96,15 +139,15 @@ func CQ(qual string, _delimiter ...rune) *compiledQual {
 		switch r {
 		case '\\':
 			escapeNext = true
+			escaped = true
 		case delimiter:
-			parts = append(parts, currentPart.String())
-			currentPart.Reset()
+			appendPart()
 		default:
 			currentPart.WriteRune(r)
 		}
 	}
 	if currentPart.Len() > 0 {
-		parts = append(parts, currentPart.String())
+		appendPart()
 	}
 
 	if len(parts) > 254 {
@@ -113,8 +156,22 @@ func CQ(qual string, _delimiter ...rune) *compiledQual {
 
 	return &compiledQual{
 		parts:     parts,
+		kinds:     kinds,
 		len:       uint8(len(parts)),
 		delimiter: delimiter,
 		index:     0,
 	}
 }
+
+// FromParts creates a compiled qual from already split and unescaped parts.
+// All parts are treated as plain keys. The slice must not be modified afterwards.
+func FromParts(parts []string) *compiledQual {
+	if len(parts) > 254 {
+		panic("qual len is too large!")
+	}
+	return &compiledQual{
+		parts:     parts,
+		len:       uint8(len(parts)),
+		delimiter: DefaultDelimiter,
+	}
+}
@@ -0,0 +1,46 @@
+package quals
+
+import "github.com/vloldik/delve/v3/pkg/idelve"
+
+// Cursor is the state of a single traversal over a qualifier. Qualifiers implementing
+// idelve.IStatelessQual are iterated without changing their state, other ones are
+// advanced with Next and must be reset with Close after the traversal.
+type Cursor struct {
+	qual      idelve.IQual
+	stateless idelve.IStatelessQual
+	pos       int
+}
+
+// Iterate starts a traversal over qual
+func Iterate(qual idelve.IQual) Cursor {
+	stateless, _ := qual.(idelve.IStatelessQual)
+	return Cursor{qual: qual, stateless: stateless}
+}
+
+// Next returns the next part of the qualifier and whether more parts follow it
+func (c *Cursor) Next() (string, bool) {
+	part, _, hasNext := c.NextSegment()
+	return part, hasNext
+}
+
+// NextSegment works like Next, but also returns kind of the segment. Segments of
+// qualifiers which implement neither idelve.IStatelessQual nor idelve.IPatternQual are keys.
+func (c *Cursor) NextSegment() (string, idelve.SegmentKind, bool) {
+	if c.stateless != nil {
+		part, kind, next, hasNext := c.stateless.SegmentAt(c.pos)
+		c.pos = next
+		return part, kind, hasNext
+	}
+	if pattern, ok := c.qual.(idelve.IPatternQual); ok {
+		return pattern.NextSegment()
+	}
+	part, hasNext := c.qual.Next()
+	return part, idelve.KeySegment, hasNext
+}
+
+// Close resets a stateful qualifier
+func (c *Cursor) Close() {
+	if c.stateless == nil {
+		c.qual.Reset()
+	}
+}
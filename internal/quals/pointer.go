@@ -0,0 +1,99 @@
+package quals
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
+
+const pointerDelimiter = '/'
+
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// ParsePointer creates a compiled qual from an RFC 6901 JSON Pointer (e.g. "/a/b~1c/0").
+// "~1" and "~0" are unescaped to "/" and "~". The "-" token is kept as is: lists append
+// to it like to "+", other containers treat it as a plain key.
+// The empty pointer has no segments.
+func ParsePointer(pointer string) (*compiledQual, error) {
+	parts, err := SplitPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	return fromPointerParts(parts), nil
+}
+
+// SplitPointer splits an RFC 6901 JSON Pointer into unescaped parts. See ParsePointer.
+func SplitPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != pointerDelimiter {
+		return nil, errors.New("json pointer must be empty or start with '/'")
+	}
+	parts := strings.Split(pointer[1:], string(pointerDelimiter))
+	if len(parts) > 254 {
+		return nil, errors.New("json pointer is too long")
+	}
+	for i, part := range parts {
+		if err := validatePointerPart(part); err != nil {
+			return nil, err
+		}
+		parts[i] = pointerUnescaper.Replace(part)
+	}
+	return parts, nil
+}
+
+// Pointer is like ParsePointer, but panics if pointer is invalid.
+func Pointer(pointer string) *compiledQual {
+	qual, err := ParsePointer(pointer)
+	if err != nil {
+		panic(err)
+	}
+	return qual
+}
+
+func validatePointerPart(part string) error {
+	for i := 0; i < len(part); i++ {
+		if part[i] == '~' && (i+1 == len(part) || (part[i+1] != '0' && part[i+1] != '1')) {
+			return errors.New("json pointer contains invalid escape '~'")
+		}
+	}
+	return nil
+}
+
+func fromPointerParts(parts []string) *compiledQual {
+	return &compiledQual{
+		parts:     parts,
+		len:       uint8(len(parts)),
+		delimiter: pointerDelimiter,
+	}
+}
+
+// ToPointer renders qual as an RFC 6901 JSON Pointer.
+// Special segments (wildcards) are rendered as plain keys.
+func ToPointer(qual idelve.IQual) string {
+	if compiled, ok := qual.(*compiledQual); ok {
+		return partsToPointer(compiled.parts)
+	}
+
+	cursor := Iterate(qual)
+	defer cursor.Close()
+	var parts []string
+	for hasNext := true; hasNext; {
+		var part string
+		part, hasNext = cursor.Next()
+		parts = append(parts, part)
+	}
+	return partsToPointer(parts)
+}
+
+func partsToPointer(parts []string) string {
+	var builder strings.Builder
+	for _, part := range parts {
+		builder.WriteByte(pointerDelimiter)
+		pointerEscaper.WriteString(&builder, part)
+	}
+	return builder.String()
+}
@@ -0,0 +1,19 @@
+package quals
+
+import "github.com/vloldik/delve/v3/pkg/idelve"
+
+const (
+	Wildcard  = "*"  // Wildcard is an unescaped segment matching any key
+	Recursive = "**" // Recursive is an unescaped segment matching zero or more levels
+)
+
+// segmentKind returns kind of a raw (still escaped) segment
+func segmentKind(raw string) idelve.SegmentKind {
+	switch raw {
+	case Wildcard:
+		return idelve.WildcardSegment
+	case Recursive:
+		return idelve.RecursiveSegment
+	}
+	return idelve.KeySegment
+}
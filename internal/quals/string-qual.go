@@ -1,6 +1,9 @@
 package quals
 
 import (
+	"strings"
+	"unicode/utf8"
+
 	"github.com/vloldik/delve/v3/internal/defaultval"
 	"github.com/vloldik/delve/v3/pkg/idelve"
 )
@@ -23,6 +26,66 @@ func (sq *stringQual) Next() (string, bool) {
 	return sq.getNextPart(), sq.qual != ""
 }
 
+func (sq *stringQual) NextSegment() (string, idelve.SegmentKind, bool) {
+	raw := sq.qual
+	if i := strings.IndexRune(raw, sq.delimiter); i >= 0 {
+		raw = raw[:i]
+	}
+	kind := segmentKind(raw)
+	part, hasNext := sq.Next()
+	return part, kind, hasNext
+}
+
+// SegmentAt parses the part starting at byte offset pos of the initial qualifier
+// without changing the qualifier state. Parts without escapes don't allocate.
+func (sq *stringQual) SegmentAt(pos int) (string, idelve.SegmentKind, int, bool) {
+	qual := sq._initQual
+	if pos > len(qual) {
+		return "", idelve.KeySegment, pos, false
+	}
+	end, escaped := len(qual), false
+	for i := pos; i < len(qual); {
+		r, size := utf8.DecodeRuneInString(qual[i:])
+		if r == '\\' {
+			escaped = true
+			i += size
+			if i < len(qual) {
+				_, size = utf8.DecodeRuneInString(qual[i:])
+				i += size
+			}
+			continue
+		}
+		if r == sq.delimiter {
+			end = i
+			break
+		}
+		i += size
+	}
+	raw := qual[pos:end]
+	part := raw
+	if escaped {
+		part = unescape(raw)
+	}
+	next := end + utf8.RuneLen(sq.delimiter)
+	return part, segmentKind(raw), next, next < len(qual)
+}
+
+// unescape removes escaping backslashes from a raw part
+func unescape(raw string) string {
+	var builder strings.Builder
+	builder.Grow(len(raw))
+	escapeNext := false
+	for _, r := range raw {
+		if r == '\\' && !escapeNext {
+			escapeNext = true
+			continue
+		}
+		escapeNext = false
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
 func (sq *stringQual) getDelemiterIndex() int {
 	var escapeNext bool
 	removedCharCount := 0
@@ -61,6 +124,10 @@ func (sq *stringQual) getNextPart() string {
 	return part
 }
 
+func (sq *stringQual) String() string {
+	return sq._initQual
+}
+
 func (sq *stringQual) Reset() {
 	sq.qual = sq._initQual
 }
@@ -0,0 +1,63 @@
+package sources
+
+import (
+	"reflect"
+
+	"github.com/vloldik/delve/v3/internal/numeric"
+)
+
+// convertTo converts val to type t. Assignable values are used as is, numbers are
+// converted with numeric.AnyToNumeric (rejecting lossy conversions), values of the same
+// kind are converted to named types (e.g. string to type Name string) and nil is
+// converted to the zero value of nilable types.
+func convertTo(val any, t reflect.Type) (reflect.Value, bool) {
+	if val == nil {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+			return reflect.Zero(t), true
+		}
+		return reflect.Value{}, false
+	}
+	v := reflect.ValueOf(val)
+	if v.Type().AssignableTo(t) {
+		return v, true
+	}
+	if converted, ok := convertNumeric(val, t.Kind()); ok {
+		return reflect.ValueOf(converted).Convert(t), true
+	}
+	if v.Kind() == t.Kind() && v.Type().ConvertibleTo(t) {
+		return v.Convert(t), true
+	}
+	return reflect.Value{}, false
+}
+
+// convertNumeric converts val to the builtin numeric type of kind
+func convertNumeric(val any, kind reflect.Kind) (any, bool) {
+	switch kind {
+	case reflect.Int:
+		return numeric.AnyToNumeric[int](val)
+	case reflect.Int8:
+		return numeric.AnyToNumeric[int8](val)
+	case reflect.Int16:
+		return numeric.AnyToNumeric[int16](val)
+	case reflect.Int32:
+		return numeric.AnyToNumeric[int32](val)
+	case reflect.Int64:
+		return numeric.AnyToNumeric[int64](val)
+	case reflect.Uint:
+		return numeric.AnyToNumeric[uint](val)
+	case reflect.Uint8:
+		return numeric.AnyToNumeric[uint8](val)
+	case reflect.Uint16:
+		return numeric.AnyToNumeric[uint16](val)
+	case reflect.Uint32:
+		return numeric.AnyToNumeric[uint32](val)
+	case reflect.Uint64:
+		return numeric.AnyToNumeric[uint64](val)
+	case reflect.Float32:
+		return numeric.AnyToNumeric[float32](val)
+	case reflect.Float64:
+		return numeric.AnyToNumeric[float64](val)
+	}
+	return nil, false
+}
@@ -0,0 +1,49 @@
+package sources
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// ShallowCopy returns a copy of a container sharing its elements with the original:
+// maps and slices (including typed ones) are copied, a pointer to a struct is replaced
+// with a pointer to a copy of the struct. Returns false for other values, including
+// struct values, arrays and custom sources.
+func ShallowCopy(val any) (any, bool) {
+	switch typed := val.(type) {
+	case map[string]any:
+		if typed == nil {
+			return map[string]any{}, true
+		}
+		return maps.Clone(typed), true
+	case []any:
+		return slices.Clone(typed), true
+	}
+
+	v := reflect.ValueOf(val)
+	switch v.Kind() {
+	case reflect.Map:
+		if typedMapSource(v) == nil {
+			return nil, false
+		}
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), iter.Value())
+		}
+		return copied.Interface(), true
+	case reflect.Slice:
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(copied, v)
+		return copied.Interface(), true
+	case reflect.Pointer:
+		if v.IsNil() || v.Elem().Kind() != reflect.Struct {
+			return nil, false
+		}
+		copied := reflect.New(v.Elem().Type())
+		copied.Elem().Set(v.Elem())
+		return copied.Interface(), true
+	}
+	return nil, false
+}
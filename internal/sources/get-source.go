@@ -1,6 +1,11 @@
 package sources
 
-import "github.com/vloldik/delve/v3/pkg/idelve"
+import (
+	"encoding"
+	"reflect"
+
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
 
 func GetSource(unknown any) idelve.ISource {
 	switch typed := unknown.(type) {
@@ -11,6 +16,107 @@ func GetSource(unknown any) idelve.ISource {
 	case idelve.ISource:
 		return typed
 	default:
+		return reflectSource(reflect.ValueOf(unknown))
+	}
+}
+
+// GetAddressableSource is like GetSource, but structs and arrays passed by value are
+// copied into new addressable values, so that they can be modified through the source.
+func GetAddressableSource(unknown any) idelve.ISource {
+	v := reflect.ValueOf(unknown)
+	if _, ok := unknown.(idelve.ISource); ok || v.Kind() != reflect.Struct && v.Kind() != reflect.Array {
+		return GetSource(unknown)
+	}
+	addressable := reflect.New(v.Type()).Elem()
+	addressable.Set(v)
+	return reflectSource(addressable)
+}
+
+// reflectSource creates a source for structs, typed slices, arrays and typed maps.
+// Returns nil for other values and for scalar-like types (see isScalarType).
+func reflectSource(v reflect.Value) idelve.ISource {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if !isScalarType(v.Type()) {
+			return sliceSource(v)
+		}
+	case reflect.Map:
+		if mapSource := typedMapSource(v); mapSource != nil {
+			return mapSource
+		}
+	case reflect.Struct, reflect.Pointer, reflect.Interface:
+		if structSource := structSource(v); structSource != nil && !isScalarType(structSource.value.Type()) {
+			return structSource
+		}
+	}
+	return nil
+}
+
+var textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+
+// isScalarType reports whether values of t are single values despite their kind, so they
+// are not navigated into: types marshaled as text (time.Time, netip.Addr, uuid-like arrays)
+// and structs without accessible fields (big.Int).
+func isScalarType(t reflect.Type) bool {
+	if t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) {
+		return true
+	}
+	return t.Kind() == reflect.Struct && len(cachedFields(t).list) == 0
+}
+
+// elemSource creates a source for a nested value. Structs and arrays are wrapped
+// directly to keep them addressable, other values are passed to GetSource. Nil maps
+// have no source, so that setting into them assigns a new map to the element instead.
+func elemSource(v reflect.Value) idelve.ISource {
+	switch v.Kind() {
+	case reflect.Struct, reflect.Array:
+		return reflectSource(v)
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+	}
+	return GetSource(v.Interface())
+}
+
+// innerSourcer is implemented by sources which provide nested sources themselves,
+// e.g. to keep nested structs addressable
+type innerSourcer interface {
+	Inner(string) idelve.ISource
+}
+
+// Inner returns a source for the container stored at key of source.
+// Returns nil if the key is missing or its value is not a container.
+func Inner(source idelve.ISource, key string) idelve.ISource {
+	if sourcer, ok := source.(innerSourcer); ok {
+		return sourcer.Inner(key)
+	}
+	val, ok := source.Get(key)
+	if !ok {
 		return nil
 	}
+	return GetSource(val)
+}
+
+// IsList reports whether source is addressed by integer indices
+func IsList(source idelve.ISource) bool {
+	switch source.(type) {
+	case *ListSource, *SliceSource:
+		return true
+	}
+	return false
+}
+
+// Unwrap returns the raw data wrapped by source: the map of a MapSource,
+// the slice of a list, the value of a reflection-based source, or the source
+// itself for custom sources.
+func Unwrap(source idelve.ISource) any {
+	switch typed := source.(type) {
+	case MapSource:
+		return map[string]any(typed)
+	case interface{ Raw() any }:
+		return typed.Raw()
+	default:
+		return source
+	}
 }
@@ -0,0 +1,13 @@
+package sources
+
+// Inserter is implemented by list sources which support inserting elements
+// before an index (shifting following elements) in addition to Set.
+type Inserter interface {
+	Insert(string, any) bool
+}
+
+// IsAppend reports whether key of a list addresses the element after the last one:
+// "+" or the RFC 6901 JSON Pointer "-" token.
+func IsAppend(key string) bool {
+	return key == "+" || key == "-"
+}
@@ -34,7 +34,7 @@ func (fl *ListSource) Get(uncasted string) (any, bool) {
 }
 
 func (fl *ListSource) Set(uncasted string, val any) bool {
-	if uncasted == "+" {
+	if IsAppend(uncasted) {
 		fl.list = append(fl.list, val)
 		return true
 	}
@@ -45,3 +45,51 @@ func (fl *ListSource) Set(uncasted string, val any) bool {
 		return false
 	}
 }
+
+// Delete removes element by index (negative indices are counted from the end)
+// and shifts the following elements to the left.
+func (fl *ListSource) Delete(uncasted string) bool {
+	index, ok := fl.parseIndex(uncasted)
+	if !ok {
+		return false
+	}
+	copy(fl.list[index:], fl.list[index+1:])
+	fl.list[len(fl.list)-1] = nil
+	fl.list = fl.list[:len(fl.list)-1]
+	return true
+}
+
+// Insert inserts val before the element at index, shifting following elements to the right.
+// Index equal to the list length, "+" or "-" appends to the list.
+func (fl *ListSource) Insert(uncasted string, val any) bool {
+	index := len(fl.list)
+	if !IsAppend(uncasted) && uncasted != strconv.Itoa(index) {
+		var ok bool
+		if index, ok = fl.parseIndex(uncasted); !ok {
+			return false
+		}
+	}
+	fl.list = append(fl.list, nil)
+	copy(fl.list[index+1:], fl.list[index:])
+	fl.list[index] = val
+	return true
+}
+
+// Len returns current length of the list
+func (fl *ListSource) Len() int {
+	return len(fl.list)
+}
+
+// Raw returns the underlying slice
+func (fl *ListSource) Raw() any {
+	return fl.list
+}
+
+// Range calls f for each index (formatted as string) and element until f returns false
+func (fl *ListSource) Range(f func(key string, val any) bool) {
+	for i, v := range fl.list {
+		if !f(strconv.Itoa(i), v) {
+			return
+		}
+	}
+}
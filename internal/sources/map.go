@@ -12,3 +12,27 @@ func (fm MapSource) Set(key string, val any) bool {
 	fm[key] = val
 	return true
 }
+
+// Delete removes key from the map. Returns false if key does not exist.
+func (fm MapSource) Delete(key string) bool {
+	if _, ok := fm[key]; !ok {
+		return false
+	}
+	delete(fm, key)
+	return true
+}
+
+// Range calls f for each key and value of the map until f returns false.
+// Order of keys is not specified.
+func (fm MapSource) Range(f func(key string, val any) bool) {
+	for k, v := range fm {
+		if !f(k, v) {
+			return
+		}
+	}
+}
+
+// Len returns the number of keys in the map
+func (fm MapSource) Len() int {
+	return len(fm)
+}
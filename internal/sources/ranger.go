@@ -0,0 +1,40 @@
+package sources
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
+
+// Ranger is implemented by sources which can enumerate their keys. It is the part of
+// idelve.IRanger required for traversal, so custom sources without Len are traversed too.
+type Ranger interface {
+	Range(func(key string, val any) bool)
+}
+
+// Item is a key and a value of a container
+type Item struct {
+	Key string
+	Val any
+}
+
+// Items collects keys and values of source, nil if source can't be enumerated.
+// Keys of sources other than lists are sorted if sorted is set.
+func Items(source idelve.ISource, sorted bool) []Item {
+	ranger, ok := source.(Ranger)
+	if !ok {
+		return nil
+	}
+	var items []Item
+	ranger.Range(func(key string, val any) bool {
+		items = append(items, Item{Key: key, Val: val})
+		return true
+	})
+	if sorted && !IsList(source) {
+		slices.SortFunc(items, func(a, b Item) int {
+			return cmp.Compare(a.Key, b.Key)
+		})
+	}
+	return items
+}
@@ -0,0 +1,10 @@
+package sources
+
+// Resizable is implemented by sources wrapping a slice header. Such sources are
+// created on the fly from the value stored in a parent container, so when their
+// length changes (append, delete) the parent still holds the old header and must
+// be updated with Raw.
+type Resizable interface {
+	Len() int
+	Raw() any
+}
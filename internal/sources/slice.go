@@ -0,0 +1,143 @@
+package sources
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
+
+// SliceSource provides access to typed slices ([]T) and arrays ([N]T) by index.
+// Set converts values to the element type the same way as StructSource does.
+// Arrays can't be resized and can only be modified if they are addressable,
+// e.g. a field of a struct passed by pointer.
+type SliceSource struct {
+	value reflect.Value
+}
+
+// NewSlice creates a source for a slice or an array. Returns nil for other values.
+func NewSlice(unknown any) *SliceSource {
+	return sliceSource(reflect.ValueOf(unknown))
+}
+
+func sliceSource(v reflect.Value) *SliceSource {
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil
+	}
+	return &SliceSource{value: v}
+}
+
+func (ss *SliceSource) parseIndex(stringIndex string) (int, bool) {
+	key, err := strconv.Atoi(stringIndex)
+	if err != nil {
+		return -1, false
+	}
+	if key < 0 {
+		key = ss.value.Len() + key
+	}
+	if key >= ss.value.Len() || key < 0 {
+		return -1, false
+	}
+	return key, true
+}
+
+func (ss *SliceSource) isSlice() bool {
+	return ss.value.Kind() == reflect.Slice
+}
+
+// Get retrieves an element by index (passed as string)
+func (ss *SliceSource) Get(uncasted string) (any, bool) {
+	index, ok := ss.parseIndex(uncasted)
+	if !ok {
+		return nil, false
+	}
+	return ss.value.Index(index).Interface(), true
+}
+
+// Set stores val converted to the element type at index. "+" or "-" appends to a slice.
+func (ss *SliceSource) Set(uncasted string, val any) bool {
+	if IsAppend(uncasted) {
+		return ss.Insert(uncasted, val)
+	}
+	index, ok := ss.parseIndex(uncasted)
+	if !ok {
+		return false
+	}
+	element := ss.value.Index(index)
+	if !element.CanSet() {
+		return false
+	}
+	converted, ok := convertTo(val, element.Type())
+	if !ok {
+		return false
+	}
+	element.Set(converted)
+	return true
+}
+
+// Delete removes element of a slice by index and shifts the following elements to the left.
+// Always fails for arrays.
+func (ss *SliceSource) Delete(uncasted string) bool {
+	index, ok := ss.parseIndex(uncasted)
+	if !ok || !ss.isSlice() {
+		return false
+	}
+	length := ss.value.Len()
+	reflect.Copy(ss.value.Slice(index, length), ss.value.Slice(index+1, length))
+	ss.value.Index(length - 1).SetZero()
+	ss.value = ss.value.Slice(0, length-1)
+	return true
+}
+
+// Insert inserts val converted to the element type before the element at index.
+// Index equal to the slice length, "+" or "-" appends. Always fails for arrays.
+func (ss *SliceSource) Insert(uncasted string, val any) bool {
+	if !ss.isSlice() {
+		return false
+	}
+	index := ss.value.Len()
+	if !IsAppend(uncasted) && uncasted != strconv.Itoa(index) {
+		var ok bool
+		if index, ok = ss.parseIndex(uncasted); !ok {
+			return false
+		}
+	}
+	converted, ok := convertTo(val, ss.value.Type().Elem())
+	if !ok {
+		return false
+	}
+	ss.value = reflect.Append(ss.value, converted)
+	length := ss.value.Len()
+	reflect.Copy(ss.value.Slice(index+1, length), ss.value.Slice(index, length-1))
+	ss.value.Index(index).Set(converted)
+	return true
+}
+
+// Inner returns a source for a nested struct or array element sharing the
+// addressability of this slice, so that its fields can be set through it.
+func (ss *SliceSource) Inner(key string) idelve.ISource {
+	index, ok := ss.parseIndex(key)
+	if !ok {
+		return nil
+	}
+	return elemSource(ss.value.Index(index))
+}
+
+// Len returns current length of the slice or array
+func (ss *SliceSource) Len() int {
+	return ss.value.Len()
+}
+
+// Raw returns the underlying slice or array
+func (ss *SliceSource) Raw() any {
+	return ss.value.Interface()
+}
+
+// Range calls f for each index (formatted as string) and element until f returns false
+func (ss *SliceSource) Range(f func(key string, val any) bool) {
+	for i := 0; i < ss.value.Len(); i++ {
+		if !f(strconv.Itoa(i), ss.value.Index(i).Interface()) {
+			return
+		}
+	}
+}
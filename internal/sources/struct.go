@@ -0,0 +1,172 @@
+package sources
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
+
+// StructSource provides access to exported fields of a struct by field name or by
+// name from the `delve` or `json` struct tag (in that order of priority).
+// Fields of embedded structs are promoted. Fields can only be set if the struct is
+// addressable, i.e. the source was created from a pointer.
+type StructSource struct {
+	value  reflect.Value
+	fields *structFields
+}
+
+// NewStruct creates a source for a struct or a (multi-level) pointer to a struct.
+// Returns nil for other values and nil pointers.
+func NewStruct(unknown any) *StructSource {
+	return structSource(reflect.ValueOf(unknown))
+}
+
+func structSource(v reflect.Value) *StructSource {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	return &StructSource{value: v, fields: cachedFields(v.Type())}
+}
+
+// Get retrieves a field value by its name or tag name
+func (ss *StructSource) Get(key string) (any, bool) {
+	field, ok := ss.field(key)
+	if !ok {
+		return nil, false
+	}
+	return field.Interface(), true
+}
+
+// Set stores val in a field converting it to the field type. Numbers are converted
+// without loss of precision only. Fails if the struct is not addressable.
+func (ss *StructSource) Set(key string, val any) bool {
+	field, ok := ss.field(key)
+	if !ok || !field.CanSet() {
+		return false
+	}
+	converted, ok := convertTo(val, field.Type())
+	if !ok {
+		return false
+	}
+	field.Set(converted)
+	return true
+}
+
+// Range calls f for each exported field (by its primary name) until f returns false.
+// Fields of nil embedded pointers are skipped.
+func (ss *StructSource) Range(f func(key string, val any) bool) {
+	for i := range ss.fields.list {
+		field, err := ss.value.FieldByIndexErr(ss.fields.list[i].index)
+		if err != nil {
+			continue
+		}
+		if !f(ss.fields.list[i].name, field.Interface()) {
+			return
+		}
+	}
+}
+
+// Inner returns a source for a nested struct or array field sharing the addressability
+// of this struct, so that nested fields can be set through it.
+func (ss *StructSource) Inner(key string) idelve.ISource {
+	field, ok := ss.field(key)
+	if !ok {
+		return nil
+	}
+	return elemSource(field)
+}
+
+// Raw returns the wrapped struct
+func (ss *StructSource) Raw() any {
+	return ss.value.Interface()
+}
+
+func (ss *StructSource) field(key string) (reflect.Value, bool) {
+	index, ok := ss.fields.byName[key]
+	if !ok {
+		return reflect.Value{}, false
+	}
+	field, err := ss.value.FieldByIndexErr(ss.fields.list[index].index)
+	return field, err == nil
+}
+
+type structField struct {
+	name  string
+	index []int
+}
+
+// structFields is a per-type index of accessible fields
+type structFields struct {
+	list   []structField
+	byName map[string]int
+}
+
+var fieldCache sync.Map // map[reflect.Type]*structFields
+
+func cachedFields(t reflect.Type) *structFields {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.(*structFields)
+	}
+	cached, _ := fieldCache.LoadOrStore(t, buildFields(t))
+	return cached.(*structFields)
+}
+
+func buildFields(t reflect.Type) *structFields {
+	fields := &structFields{byName: map[string]int{}}
+	goNames := map[string]int{}
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() {
+			continue
+		}
+		name, tagged, skip := tagName(field)
+		if skip {
+			continue
+		}
+		if field.Anonymous && !tagged && isStructType(field.Type) {
+			// Fields of embedded structs are promoted
+			continue
+		}
+		fields.list = append(fields.list, structField{name: name, index: field.Index})
+		fields.byName[name] = len(fields.list) - 1
+		goNames[field.Name] = len(fields.list) - 1
+	}
+	// Go field names are accepted too, unless they clash with a tag name
+	for name, index := range goNames {
+		if _, exists := fields.byName[name]; !exists {
+			fields.byName[name] = index
+		}
+	}
+	return fields
+}
+
+// tagName returns field name from `delve` or `json` tag, or the Go field name
+func tagName(field reflect.StructField) (name string, tagged bool, skip bool) {
+	for _, key := range []string{"delve", "json"} {
+		tag, ok := field.Tag.Lookup(key)
+		if !ok {
+			continue
+		}
+		if tag == "-" {
+			return "", false, true
+		}
+		if name, _, _ := strings.Cut(tag, ","); name != "" {
+			return name, true, false
+		}
+	}
+	return field.Name, false, false
+}
+
+func isStructType(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
@@ -0,0 +1,154 @@
+package sources
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// TypedMapSource provides access to maps other than map[string]any: maps with string,
+// integer, float or bool keys (e.g. map[string]string, map[int]any) and maps with
+// interface keys produced by YAML decoders (map[any]any).
+// Keys are parsed from strings, Set converts values to the element type.
+type TypedMapSource struct {
+	value reflect.Value
+}
+
+// NewTypedMap creates a source for a map with supported keys. Returns nil for other values.
+func NewTypedMap(unknown any) *TypedMapSource {
+	return typedMapSource(reflect.ValueOf(unknown))
+}
+
+func typedMapSource(v reflect.Value) *TypedMapSource {
+	if v.Kind() != reflect.Map {
+		return nil
+	}
+	switch v.Type().Key().Kind() {
+	case reflect.String, reflect.Interface, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return &TypedMapSource{value: v}
+	}
+	return nil
+}
+
+// mapKey finds the map key for a string key. Interface keys are looked up as
+// strings first, then compared to the formatted keys of the map.
+func (ts *TypedMapSource) mapKey(key string) (reflect.Value, bool) {
+	keyType := ts.value.Type().Key()
+	if keyType.Kind() != reflect.Interface {
+		return parseKey(key, keyType)
+	}
+	stringKey := reflect.ValueOf(key)
+	if ts.value.MapIndex(stringKey).IsValid() {
+		return stringKey, true
+	}
+	iter := ts.value.MapRange()
+	for iter.Next() {
+		if formatKey(iter.Key()) == key {
+			return iter.Key(), true
+		}
+	}
+	return stringKey, stringKey.Type().AssignableTo(keyType)
+}
+
+// Get retrieves a value by key
+func (ts *TypedMapSource) Get(key string) (any, bool) {
+	mapKey, ok := ts.mapKey(key)
+	if !ok {
+		return nil, false
+	}
+	val := ts.value.MapIndex(mapKey)
+	if !val.IsValid() {
+		return nil, false
+	}
+	return val.Interface(), true
+}
+
+// Set stores val converted to the element type. Fails for nil maps.
+func (ts *TypedMapSource) Set(key string, val any) bool {
+	if ts.value.IsNil() {
+		return false
+	}
+	mapKey, ok := ts.mapKey(key)
+	if !ok {
+		return false
+	}
+	converted, ok := convertTo(val, ts.value.Type().Elem())
+	if !ok {
+		return false
+	}
+	ts.value.SetMapIndex(mapKey, converted)
+	return true
+}
+
+// Delete removes key from the map. Returns false if key does not exist.
+func (ts *TypedMapSource) Delete(key string) bool {
+	mapKey, ok := ts.mapKey(key)
+	if !ok || !ts.value.MapIndex(mapKey).IsValid() {
+		return false
+	}
+	ts.value.SetMapIndex(mapKey, reflect.Value{})
+	return true
+}
+
+// Range calls f for each key (formatted as string) and value until f returns false.
+// Order of keys is not specified.
+func (ts *TypedMapSource) Range(f func(key string, val any) bool) {
+	iter := ts.value.MapRange()
+	for iter.Next() {
+		if !f(formatKey(iter.Key()), iter.Value().Interface()) {
+			return
+		}
+	}
+}
+
+// Raw returns the underlying map
+func (ts *TypedMapSource) Raw() any {
+	return ts.value.Interface()
+}
+
+// parseKey parses key to a value of keyType
+func parseKey(key string, keyType reflect.Type) (reflect.Value, bool) {
+	var parsed any
+	var err error
+	switch keyType.Kind() {
+	case reflect.String:
+		parsed = key
+	case reflect.Bool:
+		parsed, err = strconv.ParseBool(key)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err = strconv.ParseInt(key, 10, keyType.Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err = strconv.ParseUint(key, 10, keyType.Bits())
+	case reflect.Float32, reflect.Float64:
+		parsed, err = strconv.ParseFloat(key, keyType.Bits())
+	default:
+		return reflect.Value{}, false
+	}
+	if err != nil {
+		return reflect.Value{}, false
+	}
+	return reflect.ValueOf(parsed).Convert(keyType), true
+}
+
+// formatKey formats a map key as string
+func formatKey(key reflect.Value) string {
+	if key.Kind() == reflect.Interface {
+		key = key.Elem()
+	}
+	switch key.Kind() {
+	case reflect.String:
+		return key.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(key.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(key.Float(), 'g', -1, key.Type().Bits())
+	case reflect.Bool:
+		return strconv.FormatBool(key.Bool())
+	}
+	return fmt.Sprint(key.Interface())
+}
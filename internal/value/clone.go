@@ -0,0 +1,113 @@
+package value
+
+import (
+	"reflect"
+
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
+
+// DeepCopy returns a copy of val which shares no maps, slices or pointers with it.
+// Maps, slices and arrays (including typed ones), structs and pointers are copied
+// recursively, sources implementing idelve.ICloner are copied with Clone, other
+// sources and scalars are returned as is, so numeric types are preserved exactly.
+// Nil maps and slices stay nil. val must not contain reference cycles.
+func DeepCopy(val any) any {
+	switch typed := val.(type) {
+	case nil:
+		return nil
+	case map[string]any:
+		if typed == nil {
+			return typed
+		}
+		result := make(map[string]any, len(typed))
+		for k, v := range typed {
+			result[k] = DeepCopy(v)
+		}
+		return result
+	case []any:
+		if typed == nil {
+			return typed
+		}
+		result := make([]any, len(typed))
+		for i, v := range typed {
+			result[i] = DeepCopy(v)
+		}
+		return result
+	case idelve.ICloner:
+		return typed.Clone()
+	case idelve.ISource:
+		return val
+	}
+	return deepCopyValue(reflect.ValueOf(val)).Interface()
+}
+
+var sourceType = reflect.TypeFor[idelve.ISource]()
+
+func deepCopyValue(v reflect.Value) reflect.Value {
+	if v.Kind() != reflect.Interface && v.Type().Implements(sourceType) && v.CanInterface() {
+		// Sources stored in typed containers are cloned only if the clone fits the container
+		if cloner, ok := v.Interface().(idelve.ICloner); ok {
+			if cloned := reflect.ValueOf(cloner.Clone()); cloned.IsValid() && cloned.Type().AssignableTo(v.Type()) {
+				return cloned
+			}
+		}
+		return v
+	}
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		result := reflect.New(v.Type()).Elem()
+		result.Set(reflect.ValueOf(DeepCopy(v.Elem().Interface())))
+		return result
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		result := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			result.SetMapIndex(iter.Key(), deepCopyValue(iter.Value()))
+		}
+		return result
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		result := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			result.Index(i).Set(deepCopyValue(v.Index(i)))
+		}
+		return result
+	case reflect.Array:
+		result := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			result.Index(i).Set(deepCopyValue(v.Index(i)))
+		}
+		return result
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		result := reflect.New(v.Type().Elem())
+		result.Elem().Set(deepCopyValue(v.Elem()))
+		return result
+	case reflect.Struct:
+		// Unexported fields can't be set through reflection, they are copied shallowly
+		result := reflect.New(v.Type()).Elem()
+		result.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if field := result.Field(i); field.CanSet() {
+				field.Set(deepCopyValue(v.Field(i)))
+			}
+		}
+		return result
+	}
+	return v
+}
+
+// Clone returns a Value holding a deep copy of the wrapped value. See DeepCopy.
+func (val *Value) Clone() *Value {
+	return New(DeepCopy(val.original))
+}
@@ -0,0 +1,237 @@
+package value
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vloldik/delve/v3/internal/defaultval"
+	"github.com/vloldik/delve/v3/internal/numeric"
+)
+
+// CoerceOptions configures Value.Coerce. The zero value allows lossy conversions.
+type CoerceOptions struct {
+	// Strict rejects conversions which lose information the way numeric getters do
+	// (fractional or out of range numbers, numbers other than 0 and 1 as booleans)
+	// and only accepts strings recognized by strconv.ParseBool as booleans.
+	Strict bool
+}
+
+// Coercer is a lenient accessor of a Value. Unlike Value getters, which only accept values
+// of the requested type (or numbers for numeric getters), it parses strings and json.Number
+// into numbers, booleans and durations and formats scalars into strings.
+// Getters return the default (or the zero value) if the value can't be converted.
+type Coercer struct {
+	original any
+	strict   bool
+}
+
+// Coerce returns a lenient accessor of the value: strings such as "42", "true" or "1m30s"
+// (e.g. from environment variables or query strings) are parsed and numbers are formatted
+// as strings. Fractional numbers are truncated for integer getters unless CoerceOptions.Strict
+// is set, out of range numbers are never converted.
+//
+// Example:
+//
+//	port := nav.Get("env.PORT").Coerce().Int(8080)
+//	debug := nav.Get("query.debug").Coerce().Bool()
+//	ratio := nav.Get("ratio").Coerce(delve.CoerceOptions{Strict: true}).Int() // 0 for "1.5"
+func (val *Value) Coerce(_opts ...CoerceOptions) Coercer {
+	return Coercer{original: val.original, strict: defaultval.WithDefaultEmpty(_opts).Strict}
+}
+
+func coerced[T any](c Coercer, convert func(any, bool) (T, bool), _default []T) T {
+	if converted, ok := convert(c.original, c.strict); ok {
+		return converted
+	}
+	return defaultval.WithDefaultEmpty(_default)
+}
+
+// coerceNumeric converts numbers, numeric strings, json.Number, durations and (unless strict) booleans to T
+func coerceNumeric[T numeric.Numeric](original any, strict bool) (T, bool) {
+	switch casted := original.(type) {
+	case string:
+		return parseNumeric[T](strings.TrimSpace(casted), strict)
+	case json.Number:
+		return parseNumeric[T](string(casted), strict)
+	case time.Duration:
+		original = int64(casted)
+	case bool:
+		if strict {
+			return 0, false
+		}
+		if casted {
+			return 1, true
+		}
+		return 0, true
+	}
+	if strict {
+		return numeric.AnyToNumeric[T](original)
+	}
+	return numeric.TruncateNumeric[T](original)
+}
+
+// parseNumeric parses s as an integer or, failing that, as a float and converts it to T
+func parseNumeric[T numeric.Numeric](s string, strict bool) (T, bool) {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return coerceNumeric[T](i, strict)
+	}
+	if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return coerceNumeric[T](u, strict)
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return coerceNumeric[T](f, strict)
+	}
+	return 0, false
+}
+
+// coerceBool converts booleans, strings and numbers to bool
+func coerceBool(original any, strict bool) (bool, bool) {
+	switch casted := original.(type) {
+	case bool:
+		return casted, true
+	case string:
+		s := strings.TrimSpace(casted)
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b, true
+		}
+		if strict {
+			return false, false
+		}
+		switch strings.ToLower(s) {
+		case "yes", "y", "on":
+			return true, true
+		case "no", "n", "off":
+			return false, true
+		}
+		return false, false
+	}
+	n, ok := coerceNumeric[float64](original, strict)
+	if !ok || strict && n != 0 && n != 1 {
+		return false, false
+	}
+	return n != 0, true
+}
+
+// coerceString formats scalars (and, unless strict, fmt.Stringer implementations) as strings
+func coerceString(original any, strict bool) (string, bool) {
+	switch casted := original.(type) {
+	case string:
+		return casted, true
+	case json.Number:
+		return string(casted), true
+	case []byte:
+		return string(casted), true
+	case time.Duration:
+		return casted.String(), true
+	}
+	rv := reflect.ValueOf(original)
+	switch rv.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), true
+	case reflect.Float32:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 32), true
+	case reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 64), true
+	case reflect.String:
+		return rv.String(), true
+	}
+	if stringer, ok := original.(fmt.Stringer); ok && !strict {
+		return stringer.String(), true
+	}
+	return "", false
+}
+
+// coerceDuration converts durations, duration strings ("1m30s") and numbers of nanoseconds to time.Duration
+func coerceDuration(original any, strict bool) (time.Duration, bool) {
+	if s, ok := original.(string); ok {
+		if d, err := time.ParseDuration(strings.TrimSpace(s)); err == nil {
+			return d, true
+		}
+	}
+	n, ok := coerceNumeric[int64](original, strict)
+	return time.Duration(n), ok
+}
+
+// Get string or default. Numbers, booleans, durations and json.Number are formatted.
+func (c Coercer) String(_default ...string) string {
+	return coerced(c, coerceString, _default)
+}
+
+// Get boolean or default. Strings are parsed with strconv.ParseBool (and, unless strict,
+// "yes", "y", "on", "no", "n" and "off" are accepted), numbers are true unless zero.
+func (c Coercer) Bool(_default ...bool) bool {
+	return coerced(c, coerceBool, _default)
+}
+
+// Get time.Duration or default. Strings are parsed with time.ParseDuration, numbers are nanoseconds.
+func (c Coercer) Duration(_default ...time.Duration) time.Duration {
+	return coerced(c, coerceDuration, _default)
+}
+
+// Get int or default
+func (c Coercer) Int(_default ...int) int {
+	return coerced(c, coerceNumeric[int], _default)
+}
+
+// Get int64 or default
+func (c Coercer) Int64(_default ...int64) int64 {
+	return coerced(c, coerceNumeric[int64], _default)
+}
+
+// Get int32 or default
+func (c Coercer) Int32(_default ...int32) int32 {
+	return coerced(c, coerceNumeric[int32], _default)
+}
+
+// Get int16 or default
+func (c Coercer) Int16(_default ...int16) int16 {
+	return coerced(c, coerceNumeric[int16], _default)
+}
+
+// Get int8 or default
+func (c Coercer) Int8(_default ...int8) int8 {
+	return coerced(c, coerceNumeric[int8], _default)
+}
+
+// Get uint or default
+func (c Coercer) Uint(_default ...uint) uint {
+	return coerced(c, coerceNumeric[uint], _default)
+}
+
+// Get uint64 or default
+func (c Coercer) Uint64(_default ...uint64) uint64 {
+	return coerced(c, coerceNumeric[uint64], _default)
+}
+
+// Get uint32 or default
+func (c Coercer) Uint32(_default ...uint32) uint32 {
+	return coerced(c, coerceNumeric[uint32], _default)
+}
+
+// Get uint16 or default
+func (c Coercer) Uint16(_default ...uint16) uint16 {
+	return coerced(c, coerceNumeric[uint16], _default)
+}
+
+// Get uint8 or default
+func (c Coercer) Uint8(_default ...uint8) uint8 {
+	return coerced(c, coerceNumeric[uint8], _default)
+}
+
+// Get float64 or default
+func (c Coercer) Float64(_default ...float64) float64 {
+	return coerced(c, coerceNumeric[float64], _default)
+}
+
+// Get float32 or default
+func (c Coercer) Float32(_default ...float32) float32 {
+	return coerced(c, coerceNumeric[float32], _default)
+}
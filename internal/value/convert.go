@@ -0,0 +1,130 @@
+package value
+
+import (
+	"reflect"
+
+	"github.com/vloldik/delve/v3/internal/defaultval"
+	"github.com/vloldik/delve/v3/internal/numeric"
+)
+
+// Convert converts original to T using the rules of Value getters: values of type T are
+// returned as is, numbers are converted to builtin numeric types unless the conversion is lossy
+// and nil is converted to interface types.
+func Convert[T any](original any) (T, bool) {
+	if casted, ok := original.(T); ok {
+		return casted, true
+	}
+	var zero T
+	if original == nil && any(zero) == nil {
+		// nil is the zero value of interface types
+		return zero, true
+	}
+	var converted any
+	var ok bool
+	switch any(zero).(type) {
+	case int:
+		converted, ok = numeric.AnyToNumeric[int](original)
+	case int8:
+		converted, ok = numeric.AnyToNumeric[int8](original)
+	case int16:
+		converted, ok = numeric.AnyToNumeric[int16](original)
+	case int32:
+		converted, ok = numeric.AnyToNumeric[int32](original)
+	case int64:
+		converted, ok = numeric.AnyToNumeric[int64](original)
+	case uint:
+		converted, ok = numeric.AnyToNumeric[uint](original)
+	case uint8:
+		converted, ok = numeric.AnyToNumeric[uint8](original)
+	case uint16:
+		converted, ok = numeric.AnyToNumeric[uint16](original)
+	case uint32:
+		converted, ok = numeric.AnyToNumeric[uint32](original)
+	case uint64:
+		converted, ok = numeric.AnyToNumeric[uint64](original)
+	case float32:
+		converted, ok = numeric.AnyToNumeric[float32](original)
+	case float64:
+		converted, ok = numeric.AnyToNumeric[float64](original)
+	}
+	if !ok {
+		return zero, false
+	}
+	return converted.(T), true
+}
+
+// See delve.SliceOf
+func SliceOf[T any](val *Value, _default ...[]T) []T {
+	if converted, ok := convertSlice[T](val.original); ok {
+		return converted
+	}
+	return defaultval.WithDefaultEmpty(_default)
+}
+
+// See delve.MapOf
+func MapOf[T any](val *Value, _default ...map[string]T) map[string]T {
+	if converted, ok := convertMap[T](val.original); ok {
+		return converted
+	}
+	return defaultval.WithDefaultEmpty(_default)
+}
+
+// convertSlice converts every element of a slice or array to T. Fails if any element can't be converted.
+func convertSlice[T any](original any) ([]T, bool) {
+	switch casted := original.(type) {
+	case []T:
+		return casted, true
+	case []any:
+		converted := make([]T, len(casted))
+		for i, element := range casted {
+			var ok bool
+			if converted[i], ok = Convert[T](element); !ok {
+				return nil, false
+			}
+		}
+		return converted, true
+	}
+	rv := reflect.ValueOf(original)
+	if kind := rv.Kind(); kind != reflect.Slice && kind != reflect.Array {
+		return nil, false
+	}
+	converted := make([]T, rv.Len())
+	for i := range converted {
+		var ok bool
+		if converted[i], ok = Convert[T](rv.Index(i).Interface()); !ok {
+			return nil, false
+		}
+	}
+	return converted, true
+}
+
+// convertMap converts every value of a string-keyed map to T. Fails if any value can't be converted.
+func convertMap[T any](original any) (map[string]T, bool) {
+	switch casted := original.(type) {
+	case map[string]T:
+		return casted, true
+	case map[string]any:
+		converted := make(map[string]T, len(casted))
+		for key, element := range casted {
+			v, ok := Convert[T](element)
+			if !ok {
+				return nil, false
+			}
+			converted[key] = v
+		}
+		return converted, true
+	}
+	rv := reflect.ValueOf(original)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	converted := make(map[string]T, rv.Len())
+	for iter := rv.MapRange(); iter.Next(); {
+		v, ok := Convert[T](iter.Value().Interface())
+		if !ok {
+			return nil, false
+		}
+		converted[iter.Key().String()] = v
+	}
+	return converted, true
+}
@@ -0,0 +1,73 @@
+package value
+
+import (
+	"encoding"
+	"reflect"
+	"sync"
+
+	"github.com/vloldik/delve/v3/internal/defaultval"
+)
+
+// converters maps reflect.Type of T to func(any) (T, error) registered by RegisterConverter
+var converters sync.Map
+
+// See delve.RegisterConverter
+func RegisterConverter[T any](convert func(any) (T, error)) {
+	if convert == nil {
+		converters.Delete(reflect.TypeFor[T]())
+		return
+	}
+	converters.Store(reflect.TypeFor[T](), convert)
+}
+
+// See delve.ValueAs
+func As[T any](val *Value, _default ...T) T {
+	if converted, ok := convertAs[T](val.original); ok {
+		return converted
+	}
+	return defaultval.WithDefaultEmpty(_default)
+}
+
+// convertAs converts original to T with a registered converter, by the rules of Value getters
+// or with encoding.TextUnmarshaler
+func convertAs[T any](original any) (T, bool) {
+	var zero T
+	if original == nil {
+		return zero, false
+	}
+	if registered, ok := converters.Load(reflect.TypeFor[T]()); ok {
+		converted, err := registered.(func(any) (T, error))(original)
+		return converted, err == nil
+	}
+	if converted, ok := Convert[T](original); ok {
+		return converted, true
+	}
+	return unmarshalText[T](original)
+}
+
+// unmarshalText converts a string or []byte to T if T or *T implements encoding.TextUnmarshaler
+func unmarshalText[T any](original any) (T, bool) {
+	var text []byte
+	switch casted := original.(type) {
+	case string:
+		text = []byte(casted)
+	case []byte:
+		text = casted
+	default:
+		var zero T
+		return zero, false
+	}
+
+	var result T
+	if t := reflect.TypeFor[T](); t.Kind() == reflect.Pointer {
+		// Pointer types such as *big.Int need a new value to unmarshal into
+		result = reflect.New(t.Elem()).Convert(t).Interface().(T)
+		if unmarshaler, ok := any(result).(encoding.TextUnmarshaler); ok && unmarshaler.UnmarshalText(text) == nil {
+			return result, true
+		}
+	} else if unmarshaler, ok := any(&result).(encoding.TextUnmarshaler); ok && unmarshaler.UnmarshalText(text) == nil {
+		return result, true
+	}
+	var zero T
+	return zero, false
+}
@@ -0,0 +1,75 @@
+package value
+
+import (
+	"reflect"
+
+	"github.com/vloldik/delve/v3/internal/numeric"
+)
+
+// NumericEqual reports whether a and b are both numbers representing the same value,
+// regardless of their types (e.g. float64(1) from JSON and int(1) from Go).
+func NumericEqual(a, b any) bool {
+	if af, ok := numeric.AnyToNumeric[float64](a); ok {
+		bf, ok := numeric.AnyToNumeric[float64](b)
+		return ok && af == bf
+	}
+	// Large integers are not exactly representable as float64
+	if ai, ok := numeric.AnyToNumeric[int64](a); ok {
+		bi, ok := numeric.AnyToNumeric[int64](b)
+		return ok && ai == bi
+	}
+	if au, ok := numeric.AnyToNumeric[uint64](a); ok {
+		bu, ok := numeric.AnyToNumeric[uint64](b)
+		return ok && au == bu
+	}
+	return false
+}
+
+// IsNumeric reports whether v holds one of the Numeric types
+func IsNumeric(v any) bool {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// DeepEqual reports whether a and b are deeply equal. Maps, slices and arrays of
+// any types are compared element by element and numbers are compared with NumericEqual.
+func DeepEqual(a, b any) bool {
+	if IsNumeric(a) && IsNumeric(b) {
+		return NumericEqual(a, b)
+	}
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	switch {
+	case isList(va) && isList(vb):
+		if va.Len() != vb.Len() {
+			return false
+		}
+		for i := 0; i < va.Len(); i++ {
+			if !DeepEqual(va.Index(i).Interface(), vb.Index(i).Interface()) {
+				return false
+			}
+		}
+		return true
+	case va.Kind() == reflect.Map && vb.Kind() == reflect.Map:
+		if va.Len() != vb.Len() || va.Type().Key() != vb.Type().Key() {
+			return false
+		}
+		iter := va.MapRange()
+		for iter.Next() {
+			other := vb.MapIndex(iter.Key())
+			if !other.IsValid() || !DeepEqual(iter.Value().Interface(), other.Interface()) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func isList(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice || v.Kind() == reflect.Array
+}
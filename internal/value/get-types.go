@@ -4,6 +4,7 @@ import (
 	"reflect"
 
 	"github.com/vloldik/delve/v3/internal/defaultval"
+	"github.com/vloldik/delve/v3/internal/numeric"
 )
 
 type Value struct {
@@ -21,8 +22,8 @@ func getTyped[T any](v *Value, _default ...T) T {
 	return defaultval.WithDefaultEmpty(_default)
 }
 
-func getNumeric[T Numeric](v *Value, _default ...T) T {
-	if casted, ok := AnyToNumeric[T](v.original); ok {
+func getNumeric[T numeric.Numeric](v *Value, _default ...T) T {
+	if casted, ok := numeric.AnyToNumeric[T](v.original); ok {
 		return casted
 	}
 	return defaultval.WithDefaultEmpty(_default)
@@ -148,57 +149,68 @@ func (val *Value) Float32(_default ...float32) float32 {
 	return getNumeric(val, _default...)
 }
 
-// Get string slice or default
+// Get string slice or default. Elements of other slices (e.g. []any) are converted like
+// the String getter does, the default is returned if any of them can't be converted.
 func (val *Value) StringSlice(_default ...[]string) []string {
-	return getTyped(val, _default...)
+	return SliceOf(val, _default...)
 }
 
-// Get bool slice or default
+// Get bool slice or default. Elements of other slices (e.g. []any) are converted like
+// the Bool getter does, the default is returned if any of them can't be converted.
 func (val *Value) BoolSlice(_default ...[]bool) []bool {
-	return getTyped(val, _default...)
+	return SliceOf(val, _default...)
 }
 
-// Get int slice or default
+// Get int slice or default. Elements of other slices (e.g. []any) are converted like
+// the Int getter does, the default is returned if any of them can't be converted.
 func (val *Value) IntSlice(_default ...[]int) []int {
-	return getTyped(val, _default...)
+	return SliceOf(val, _default...)
 }
 
-// Get int64 slice or default
+// Get int64 slice or default. Elements of other slices (e.g. []any) are converted like
+// the Int64 getter does, the default is returned if any of them can't be converted.
 func (val *Value) Int64Slice(_default ...[]int64) []int64 {
-	return getTyped(val, _default...)
+	return SliceOf(val, _default...)
 }
 
-// Get float64 slice or default
+// Get float64 slice or default. Elements of other slices (e.g. []any) are converted like
+// the Float64 getter does, the default is returned if any of them can't be converted.
 func (val *Value) Float64Slice(_default ...[]float64) []float64 {
-	return getTyped(val, _default...)
+	return SliceOf(val, _default...)
 }
 
-// Get string map or default
+// Get map[string]string or default. Values of other string-keyed maps (e.g. map[string]any) are
+// converted like the String getter does, the default is returned if any of them can't be converted.
 func (val *Value) StringMap(_default ...map[string]string) map[string]string {
-	return getTyped(val, _default...)
+	return MapOf(val, _default...)
 }
 
-// Get interface map or default
+// Get map[string]any or default. Values of other string-keyed maps (e.g. map[string]any) are
+// converted like the Interface getter does, the default is returned if any of them can't be converted.
 func (val *Value) InterfaceMap(_default ...map[string]any) map[string]any {
-	return getTyped(val, _default...)
+	return MapOf(val, _default...)
 }
 
-// Get bool map or default
+// Get map[string]bool or default. Values of other string-keyed maps (e.g. map[string]any) are
+// converted like the Bool getter does, the default is returned if any of them can't be converted.
 func (val *Value) BoolMap(_default ...map[string]bool) map[string]bool {
-	return getTyped(val, _default...)
+	return MapOf(val, _default...)
 }
 
-// Get int map or default
+// Get map[string]int or default. Values of other string-keyed maps (e.g. map[string]any) are
+// converted like the Int getter does, the default is returned if any of them can't be converted.
 func (val *Value) IntMap(_default ...map[string]int) map[string]int {
-	return getTyped(val, _default...)
+	return MapOf(val, _default...)
 }
 
-// Get int64 map or default
+// Get map[string]int64 or default. Values of other string-keyed maps (e.g. map[string]any) are
+// converted like the Int64 getter does, the default is returned if any of them can't be converted.
 func (val *Value) Int64Map(_default ...map[string]int64) map[string]int64 {
-	return getTyped(val, _default...)
+	return MapOf(val, _default...)
 }
 
-// Get float64 map or default
+// Get map[string]float64 or default. Values of other string-keyed maps (e.g. map[string]any) are
+// converted like the Float64 getter does, the default is returned if any of them can't be converted.
 func (val *Value) Float64Map(_default ...map[string]float64) map[string]float64 {
-	return getTyped(val, _default...)
+	return MapOf(val, _default...)
 }
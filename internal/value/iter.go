@@ -0,0 +1,38 @@
+package value
+
+import (
+	"iter"
+
+	"github.com/vloldik/delve/v3/internal/sources"
+)
+
+// Items returns an iterator over keys and values of a map, list (keys are indices formatted
+// as strings), Go struct or custom source implementing Range. Yields nothing for other values.
+// Lists are iterated in order, order of map keys is not specified.
+//
+// Example:
+//
+//	for key, v := range nav.Get("servers").Items() {
+//	    fmt.Println(key, v.Interface())
+//	}
+func (val *Value) Items() iter.Seq2[string, *Value] {
+	return func(yield func(string, *Value) bool) {
+		for _, item := range sources.Items(sources.GetSource(val.original), false) {
+			if !yield(item.Key, New(item.Val)) {
+				return
+			}
+		}
+	}
+}
+
+// See delve.Elements
+func Elements[T any](val *Value) iter.Seq2[string, T] {
+	return func(yield func(string, T) bool) {
+		for key, element := range val.Items() {
+			converted, _ := Convert[T](element.original)
+			if !yield(key, converted) {
+				return
+			}
+		}
+	}
+}
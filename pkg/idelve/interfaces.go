@@ -6,7 +6,29 @@ type ISource interface {
 	Set(string, any) bool
 }
 
-// Interface represents qualifier to access fields of navigator
+// IDeleter is an optional interface for sources which support removing keys.
+// Delete returns true if the key existed and was removed.
+type IDeleter interface {
+	Delete(string) bool
+}
+
+// IRanger is an optional interface for sources which can enumerate their keys.
+// Range calls f for each key and value until f returns false, Len returns the number of keys.
+// Sources implementing only Range are enumerated as well, Len is then computed by Range.
+type IRanger interface {
+	Range(f func(key string, v any) bool)
+	Len() int
+}
+
+// ICloner is an optional interface for sources which can make an independent deep copy
+// of themselves. Sources which don't implement it are shared by clones of a navigator.
+type ICloner interface {
+	Clone() ISource
+}
+
+// Interface represents qualifier to access fields of navigator.
+// Next and Reset change the state of the qualifier, so a qualifier which doesn't also
+// implement IStatelessQual must not be used by several goroutines at once.
 type IQual interface {
 	// Function to access next part of qualifier
 	Next() (string, bool)
@@ -15,3 +37,34 @@ type IQual interface {
 	// Function to get an independent copy of current qual
 	Copy() IQual
 }
+
+// SegmentKind describes how a qualifier segment is matched against keys
+type SegmentKind uint8
+
+const (
+	// KeySegment matches exactly one key equal to segment text
+	KeySegment SegmentKind = iota
+	// WildcardSegment ("*") matches every key of a map or index of a list
+	WildcardSegment
+	// RecursiveSegment ("**") matches zero or more levels of nesting
+	RecursiveSegment
+)
+
+// IPatternQual is an optional interface for qualifiers which may contain
+// special segments such as wildcards and recursive descent. Such segments are only interpreted by
+// multi-value lookups (Navigator.QGetAll), single-value lookups treat them as keys.
+type IPatternQual interface {
+	IQual
+	// Works like Next, but also returns kind of the segment
+	NextSegment() (string, SegmentKind, bool)
+}
+
+// IStatelessQual is an optional interface for qualifiers which can be iterated without
+// changing their state. Navigator uses it instead of Next and Reset, so such qualifiers
+// can be shared between goroutines (e.g. stored in package-level variables).
+type IStatelessQual interface {
+	IQual
+	// SegmentAt returns the segment starting at position pos (0 for the first segment), its kind
+	// and the position of the following segment, which is only valid if hasNext is true.
+	SegmentAt(pos int) (part string, kind SegmentKind, next int, hasNext bool)
+}
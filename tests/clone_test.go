@@ -0,0 +1,104 @@
+package delve_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vloldik/delve/v3"
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
+
+// clonableSource is a deletableSource which can be deep copied
+type clonableSource struct {
+	deletableSource
+}
+
+func (cs clonableSource) Clone() idelve.ISource {
+	copied := deletableSource{}
+	for k, v := range cs.deletableSource {
+		copied[k] = v
+	}
+	return clonableSource{copied}
+}
+
+func TestClone(t *testing.T) {
+	t.Run("Clone is independent and preserves numeric types", func(t *testing.T) {
+		data := map[string]any{
+			"int":     int64(1),
+			"uint":    uint8(2),
+			"float":   float32(3.5),
+			"list":    []any{map[string]any{"a": 1}},
+			"typed":   []int{1, 2},
+			"labels":  map[string]string{"env": "prod"},
+			"address": &structAddress{City: "Paris"},
+		}
+		nav := delve.New(data)
+		clone := nav.Clone()
+		if !reflect.DeepEqual(clone.Source(), nav.Source()) {
+			t.Fatalf("clone %v differs from original %v", clone.Source(), nav.Source())
+		}
+
+		clone.Set("list.0.a", 2)
+		clone.Set("typed.0", 10)
+		clone.Set("labels.env", "dev")
+		clone.Set("address.city", "Lyon")
+		clone.Set("list.+", 3)
+		if nav.Get("list.0.a").Int() != 1 || nav.Get("typed.0").Int() != 1 ||
+			nav.Get("labels.env").String() != "prod" || nav.Get("address.city").String() != "Paris" ||
+			len(data["list"].([]any)) != 1 {
+			t.Errorf("changes of the clone leaked into the original: %v", data)
+		}
+		if _, ok := clone.Get("uint").Interface().(uint8); !ok {
+			t.Errorf("uint8 type should be preserved, got %T", clone.Get("uint").Interface())
+		}
+	})
+
+	t.Run("Sub-navigator clone", func(t *testing.T) {
+		nav := delve.New(map[string]any{"a": map[string]any{"b": []any{1}}})
+		sub := nav.GetNavigator("a").Clone()
+		sub.Set("b.0", 2)
+		if nav.Get("a.b.0").Int() != 1 {
+			t.Error("sub-navigator clone should not alias the original")
+		}
+	})
+
+	t.Run("Clone of a struct sub-navigator is writable", func(t *testing.T) {
+		user := &structUser{Name: "Ann", Address: structAddress{City: "Paris"}}
+		nav := delve.New(map[string]any{"user": user})
+		for path, field := range map[string]string{"user": "name", "user.address": "city"} {
+			if !nav.GetNavigator(path).Clone().Set(field, "changed") {
+				t.Errorf("Set %s on a clone of %s failed", field, path)
+			}
+		}
+		if user.Name != "Ann" || user.Address.City != "Paris" {
+			t.Errorf("clone should not alias the original, got %+v", user)
+		}
+	})
+
+	t.Run("Custom sources implementing ICloner", func(t *testing.T) {
+		original := clonableSource{deletableSource{"a": 1}}
+		clone := delve.From(original).Clone()
+		clone.Set("a", 2)
+		if original.deletableSource["a"] != 1 {
+			t.Error("cloner source should be copied")
+		}
+
+		shared := deletableSource{"a": 1}
+		delve.From(shared).Clone().Set("a", 2)
+		if shared["a"] != 2 {
+			t.Error("custom source without ICloner should be shared")
+		}
+	})
+
+	t.Run("Value clone", func(t *testing.T) {
+		nav := delve.New(map[string]any{"a": map[string]any{"b": 1}})
+		cloned := nav.Get("a").Clone().InterfaceMap()
+		cloned["b"] = 2
+		if nav.Get("a.b").Int() != 1 {
+			t.Error("Value.Clone should not alias the original")
+		}
+		if !nav.Get("missing").Clone().IsNil() {
+			t.Error("clone of a nil value should be nil")
+		}
+	})
+}
@@ -0,0 +1,124 @@
+package delve_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/vloldik/delve/v3"
+)
+
+func TestCoerce(t *testing.T) {
+	nav := delve.New(map[string]any{
+		"port":     "8080",
+		"spaced":   " 42 ",
+		"ratio":    "1.5",
+		"big":      "300",
+		"negative": "-1",
+		"huge":     3e10,
+		"nan":      "NaN",
+		"overflow": 1e40,
+		"float":    2.75,
+		"number":   json.Number("12"),
+		"fraction": json.Number("0.5"),
+		"yes":      "yes",
+		"true":     "true",
+		"one":      1,
+		"two":      2,
+		"timeout":  "1m30s",
+		"duration": 2 * time.Second,
+		"flag":     true,
+		"name":     "Alice",
+		"list":     []any{1},
+	})
+	lenient := func(path string) delve.Coercer { return nav.Get(path).Coerce() }
+	strict := func(path string) delve.Coercer { return nav.Get(path).Coerce(delve.CoerceOptions{Strict: true}) }
+
+	t.Run("Numbers", func(t *testing.T) {
+		for _, tc := range []struct {
+			name      string
+			got, want any
+		}{
+			{"port", lenient("port").Int(), 8080},
+			{"spaced", lenient("spaced").Int64(), int64(42)},
+			{"ratio as int", lenient("ratio").Int(), 1},
+			{"ratio as float", lenient("ratio").Float64(), 1.5},
+			{"big as uint8", lenient("big").Uint8(1), uint8(1)},
+			{"big as int8", lenient("big").Int8(1), int8(1)},
+			{"big as int16", lenient("big").Int16(), int16(300)},
+			{"negative as uint", lenient("negative").Uint(1), uint(1)},
+			{"negative as int8", lenient("negative").Int8(), int8(-1)},
+			{"huge as int32", lenient("huge").Int32(1), int32(1)},
+			{"huge as uint64", lenient("huge").Uint64(), uint64(3e10)},
+			{"huge as float32", lenient("huge").Float32(), float32(3e10)},
+			{"NaN as int", lenient("nan").Int(1), 1},
+			{"overflow as float32", lenient("overflow").Float32(1), float32(1)},
+			{"overflow as float64", lenient("overflow").Float64(), 1e40},
+			{"float as int", lenient("float").Int(), 2},
+			{"json.Number", lenient("number").Uint16(), uint16(12)},
+			{"bool", lenient("flag").Int(), 1},
+			{"duration", lenient("duration").Int64(), int64(2 * time.Second)},
+			{"name", lenient("name").Int(7), 7},
+			{"missing", lenient("missing").Float32(), float32(0)},
+			{"strict port", strict("port").Int32(), int32(8080)},
+			{"strict ratio as int", strict("ratio").Int(-1), -1},
+			{"strict ratio as float", strict("ratio").Float32(), float32(1.5)},
+			{"strict big as uint8", strict("big").Uint8(1), uint8(1)},
+			{"strict float as int", strict("float").Int(-1), -1},
+			{"strict fraction", strict("fraction").Int64(-1), int64(-1)},
+			{"strict bool", strict("flag").Int(-1), -1},
+		} {
+			if tc.got != tc.want {
+				t.Errorf("%s: got %v (%T), want %v (%T)", tc.name, tc.got, tc.got, tc.want, tc.want)
+			}
+		}
+	})
+
+	t.Run("Booleans", func(t *testing.T) {
+		for _, tc := range []struct {
+			name      string
+			got, want bool
+		}{
+			{"true", lenient("true").Bool(), true},
+			{"yes", lenient("yes").Bool(), true},
+			{"one", lenient("one").Bool(), true},
+			{"two", lenient("two").Bool(), true},
+			{"name", lenient("name").Bool(true), true},
+			{"strict yes", strict("yes").Bool(), false},
+			{"strict one", strict("one").Bool(), true},
+			{"strict two", strict("two").Bool(), false},
+		} {
+			if tc.got != tc.want {
+				t.Errorf("%s: got %v, want %v", tc.name, tc.got, tc.want)
+			}
+		}
+	})
+
+	t.Run("Strings and durations", func(t *testing.T) {
+		for _, tc := range []struct {
+			name      string
+			got, want any
+		}{
+			{"float", lenient("float").String(), "2.75"},
+			{"one", lenient("one").String(), "1"},
+			{"flag", lenient("flag").String(), "true"},
+			{"number", strict("number").String(), "12"},
+			{"duration", lenient("duration").String(), "2s"},
+			{"list", lenient("list").String("-"), "-"},
+			{"timeout", lenient("timeout").Duration(), 90 * time.Second},
+			{"duration value", strict("duration").Duration(), 2 * time.Second},
+			{"nanoseconds", lenient("one").Duration(), time.Duration(1)},
+			{"invalid", lenient("name").Duration(time.Minute), time.Minute},
+		} {
+			if tc.got != tc.want {
+				t.Errorf("%s: got %v, want %v", tc.name, tc.got, tc.want)
+			}
+		}
+	})
+
+	t.Run("Getters stay strict", func(t *testing.T) {
+		if nav.Get("port").Int() != 0 || nav.Get("true").Bool() {
+			t.Error("plain getters should not parse strings")
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package delve_test
+
+import (
+	"errors"
+	"log/slog"
+	"math/big"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vloldik/delve/v3"
+)
+
+// convertColor is an enum converted by a registered converter
+type convertColor uint8
+
+const (
+	colorUnknown convertColor = iota
+	colorRed
+	colorGreen
+)
+
+// convertName always gets upper-cased by its converter, even if the value already has this type
+type convertName string
+
+func TestGetAs(t *testing.T) {
+	delve.RegisterConverter(func(v any) (convertColor, error) {
+		switch v {
+		case "red":
+			return colorRed, nil
+		case "green":
+			return colorGreen, nil
+		}
+		return colorUnknown, errors.New("unknown color")
+	})
+	delve.RegisterConverter(func(v any) (convertName, error) {
+		s, ok := v.(convertName)
+		if !ok {
+			return "", errors.New("not a name")
+		}
+		return convertName(strings.ToUpper(string(s))), nil
+	})
+	t.Cleanup(func() {
+		delve.RegisterConverter[convertColor](nil)
+		delve.RegisterConverter[convertName](nil)
+	})
+
+	nav := delve.New(map[string]any{
+		"color":    "green",
+		"bad":      "purple",
+		"name":     convertName("alice"),
+		"addr":     "192.168.0.1",
+		"level":    "WARN",
+		"big":      "123456789012345678901234567890",
+		"time":     []byte("2024-01-02T03:04:05Z"),
+		"port":     8080.0,
+		"fraction": 1.5,
+		"text":     "hello",
+	})
+
+	if got := delve.GetAs[convertColor](nav, "color"); got != colorGreen {
+		t.Errorf("color = %v, want %v", got, colorGreen)
+	}
+	if got := delve.GetAs(nav, "bad", colorRed); got != colorRed {
+		t.Errorf("failed conversion should return the default, got %v", got)
+	}
+	if got := delve.GetAs(nav, "missing", colorRed); got != colorRed {
+		t.Errorf("missing value should return the default, got %v", got)
+	}
+	if got := delve.GetAs[convertName](nav, "name"); got != "ALICE" {
+		t.Errorf("registered converter should be used before type assertion, got %q", got)
+	}
+
+	if got := delve.GetAs[netip.Addr](nav, "addr"); got != netip.MustParseAddr("192.168.0.1") {
+		t.Errorf("addr = %v", got)
+	}
+	if got := delve.GetAs(nav, "text", netip.IPv6Loopback()); got != netip.IPv6Loopback() {
+		t.Errorf("invalid text should return the default, got %v", got)
+	}
+	if got := delve.GetAs(nav, "level", slog.LevelInfo); got != slog.LevelWarn {
+		t.Errorf("level = %v, want WARN", got)
+	}
+	if got := delve.GetAs[*big.Int](nav, "big"); got == nil || got.String() != "123456789012345678901234567890" {
+		t.Errorf("big = %v", got)
+	}
+	if got := delve.ValueAs[time.Time](nav.Get("time")); !got.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("time = %v", got)
+	}
+
+	if got := delve.GetAs[uint16](nav, "port"); got != 8080 {
+		t.Errorf("port = %v, want 8080", got)
+	}
+	if got := delve.GetAs(nav, "fraction", -1); got != -1 {
+		t.Errorf("lossy numeric conversion should return the default, got %v", got)
+	}
+	if got := delve.QGetAs[string](nav, delve.Q("text")); got != "hello" {
+		t.Errorf("text = %q", got)
+	}
+}
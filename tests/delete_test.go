@@ -0,0 +1,106 @@
+package delve_test
+
+import (
+	"testing"
+
+	"github.com/vloldik/delve/v3"
+	"github.com/vloldik/delve/v3/internal/quals"
+)
+
+type deletableSource map[string]any
+
+func (ds deletableSource) Get(key string) (any, bool) {
+	v, ok := ds[key]
+	return v, ok
+}
+
+func (ds deletableSource) Set(key string, val any) bool {
+	ds[key] = val
+	return true
+}
+
+func (ds deletableSource) Delete(key string) bool {
+	_, ok := ds[key]
+	delete(ds, key)
+	return ok
+}
+
+func TestDeleteFunction(t *testing.T) {
+	t.Run("Delete key from nested map", func(t *testing.T) {
+		m := map[string]any{"a": map[string]any{"b": 1, "c": 2}}
+		nav := delve.New(m)
+		if !nav.QDelete(quals.CQ("a.b")) {
+			t.Fatal("QDelete failed")
+		}
+		inner := m["a"].(map[string]any)
+		if _, ok := inner["b"]; ok {
+			t.Error("Key a.b should be removed")
+		}
+		if inner["c"] != 2 {
+			t.Error("Key a.c should be untouched")
+		}
+	})
+
+	t.Run("Delete missing key", func(t *testing.T) {
+		nav := delve.New(map[string]any{"a": map[string]any{}})
+		if nav.Delete("a.b") {
+			t.Error("Delete of a missing key should fail")
+		}
+		if nav.Delete("x.y") {
+			t.Error("Delete through a missing path should fail")
+		}
+	})
+
+	t.Run("Delete from nested list shifts elements", func(t *testing.T) {
+		m := map[string]any{"list": []any{0, 1, 2, 3}}
+		nav := delve.New(m)
+		if !nav.Delete("list.1") {
+			t.Fatal("Delete failed")
+		}
+		list := m["list"].([]any)
+		if len(list) != 3 || list[0] != 0 || list[1] != 2 || list[2] != 3 {
+			t.Errorf("Expected [0 2 3], got %v", list)
+		}
+	})
+
+	t.Run("Delete from list with negative index", func(t *testing.T) {
+		nav := delve.New([]any{"a", "b", "c"})
+		if !nav.QDelete(quals.Q("-1")) {
+			t.Fatal("QDelete failed")
+		}
+		if nav.Get("-1").String() != "b" {
+			t.Errorf("Expected last element b, got %v", nav.Get("-1").Interface())
+		}
+		if nav.Delete("5") {
+			t.Error("Delete with out of range index should fail")
+		}
+	})
+
+	t.Run("Delete in list element's map", func(t *testing.T) {
+		list := []any{map[string]any{"key": "value"}}
+		nav := delve.New(list)
+		if !nav.Delete("0.key") {
+			t.Fatal("Delete failed")
+		}
+		if len(list[0].(map[string]any)) != 0 {
+			t.Error("Map should be empty")
+		}
+	})
+
+	t.Run("Delete with custom source", func(t *testing.T) {
+		nav := delve.From(deletableSource{"a": 1})
+		if !nav.Delete("a") {
+			t.Fatal("Delete failed")
+		}
+		if _, ok := nav.QGetRaw(quals.Q("a")); ok {
+			t.Error("Key should be removed")
+		}
+	})
+
+	t.Run("Delete with source which can not delete", func(t *testing.T) {
+		nav := delve.From(mockSource{})
+		if nav.Delete("a") {
+			t.Error("Delete should fail without IDeleter")
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package delve_test
+
+import (
+	"fmt"
+	"math/big"
+	"net/netip"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/vloldik/delve/v3"
+)
+
+func changeStrings(changes delve.Changes) []string {
+	result := make([]string, 0, len(changes))
+	for _, c := range changes {
+		result = append(result, fmt.Sprintf("%v %v %v->%v", c.Kind, c.Path, c.Old.Interface(), c.New.Interface()))
+	}
+	return result
+}
+
+func TestDiff(t *testing.T) {
+	a := delve.New(map[string]any{
+		"name":  "app",
+		"port":  float64(8080),
+		"db":    map[string]any{"host": "localhost", "user": "root"},
+		"tags":  []any{"a", "b", "c"},
+		"extra": []any{1},
+	})
+	b := delve.New(map[string]any{
+		"name":  "app",
+		"port":  8080,
+		"db":    map[string]any{"host": "db", "pass": "secret"},
+		"tags":  []any{"a", "x"},
+		"extra": map[string]any{},
+		"new":   true,
+	})
+
+	expected := []string{
+		"modified db.host localhost->db",
+		"added db.pass <nil>->secret",
+		"removed db.user root-><nil>",
+		"modified extra [1]->map[]",
+		"added new <nil>->true",
+		"modified tags.1 b->x",
+		"removed tags.2 c-><nil>",
+	}
+	if got := changeStrings(delve.Diff(a, b, delve.DiffOptions{NumericEqual: true})); !slices.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	strict := changeStrings(delve.Diff(a, b))
+	if !slices.Contains(strict, "modified port 8080->8080") {
+		t.Errorf("Numbers of different types should differ without NumericEqual, got %v", strict)
+	}
+
+	if changes := delve.Diff(a, a); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %v", changeStrings(changes))
+	}
+}
+
+func TestDiffPatch(t *testing.T) {
+	tests := []struct{ a, b string }{
+		{`{"a":1,"b":{"c":[1,2,3,4]},"d":"x"}`, `{"a":2,"b":{"c":[1,5]},"e":{"f":null}}`},
+		{`{"list":[]}`, `{"list":[{"a":1},{"b":2}]}`},
+		{`{"a~b":{"c/d":1}}`, `{"a~b":{"c/d":2}}`},
+	}
+	for _, test := range tests {
+		a := delve.New(jsonMap(t, test.a))
+		b := delve.New(jsonMap(t, test.b))
+		if err := a.ApplyPatch(delve.Diff(a, b).Patch()); err != nil {
+			t.Fatalf("Failed to apply diff patch: %v", err)
+		}
+		if got := toJSONValue(t, a.Source()); !reflect.DeepEqual(got, jsonMap(t, test.b)) {
+			t.Errorf("Expected %v, got %v", test.b, got)
+		}
+	}
+}
+
+func TestDiffScalarStructs(t *testing.T) {
+	a := delve.New(map[string]any{"t": time.Unix(0, 0), "addr": netip.MustParseAddr("10.0.0.1"), "n": big.NewInt(1)})
+	b := delve.New(map[string]any{"t": time.Unix(1000, 0), "addr": netip.MustParseAddr("10.0.0.2"), "n": big.NewInt(2)})
+
+	changes := delve.Diff(a, b)
+	if len(changes) != 3 {
+		t.Fatalf("Expected 3 changes, got %v", changeStrings(changes))
+	}
+	for _, c := range changes {
+		if c.Kind != delve.Modified {
+			t.Errorf("Expected %v to be modified as a whole, got %v", c.Path, c.Kind)
+		}
+	}
+	if got := changes[2].New.Interface(); got != time.Unix(1000, 0) {
+		t.Errorf("Expected the new time, got %v", got)
+	}
+	if changes := delve.Diff(a, a); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %v", changeStrings(changes))
+	}
+}
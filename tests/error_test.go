@@ -0,0 +1,87 @@
+package delve_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/vloldik/delve/v3"
+	"github.com/vloldik/delve/v3/internal/quals"
+)
+
+type readOnlySource map[string]any
+
+func (rs readOnlySource) Get(key string) (any, bool) {
+	v, ok := rs[key]
+	return v, ok
+}
+
+func (readOnlySource) Set(string, any) bool {
+	return false
+}
+
+func expectPathError(t *testing.T, err error, reason delve.PathErrorReason, index int, segment string) {
+	t.Helper()
+	var pathErr *delve.PathError
+	if !errors.As(err, &pathErr) {
+		t.Fatalf("Expected *delve.PathError, got %v", err)
+	}
+	if pathErr.Reason != reason || pathErr.Index != index || pathErr.Segment != segment {
+		t.Errorf("Expected %v at %d (%q), got %v at %d (%q)",
+			reason, index, segment, pathErr.Reason, pathErr.Index, pathErr.Segment)
+	}
+}
+
+func TestGetE(t *testing.T) {
+	nav := delve.New(map[string]any{
+		"a": map[string]any{"list": []any{1, 2}, "scalar": 5},
+	})
+
+	if v, err := nav.GetE("a.list.1"); err != nil || v.Int() != 2 {
+		t.Errorf("Expected 2 without error, got %v, %v", v, err)
+	}
+
+	_, err := nav.QGetE(quals.CQ("a.missing.x"))
+	expectPathError(t, err, delve.NotFound, 1, "missing")
+
+	_, err = nav.GetE("a.list.5")
+	expectPathError(t, err, delve.IndexOutOfRange, 2, "5")
+
+	_, err = nav.GetE("a.list.x")
+	expectPathError(t, err, delve.InvalidIndex, 2, "x")
+
+	_, err = nav.QGetE(quals.Q("a.scalar.x"))
+	expectPathError(t, err, delve.NotContainer, 2, "x")
+
+	if err.Error() == "" {
+		t.Error("Error message should not be empty")
+	}
+}
+
+func TestSetE(t *testing.T) {
+	nav := delve.New(map[string]any{"list": []any{1}})
+
+	if err := nav.SetE("a.b", 1); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+
+	expectPathError(t, nav.SetE("list.3", 1), delve.IndexOutOfRange, 1, "3")
+	expectPathError(t, nav.QSetE(quals.CQ("list.x"), 1), delve.InvalidIndex, 1, "x")
+
+	roNav := delve.From(readOnlySource{"a": map[string]any{}})
+	if err := roNav.SetE("a.b", 1); err != nil {
+		t.Errorf("Nested map should be writable, got %v", err)
+	}
+	expectPathError(t, roNav.SetE("b", 1), delve.ReadOnly, 0, "b")
+}
+
+func TestQMustPanicsWithPathError(t *testing.T) {
+	nav := delve.New(map[string]any{"a": 1})
+	defer func() {
+		err, ok := recover().(error)
+		if !ok {
+			t.Fatal("Expected panic with error")
+		}
+		expectPathError(t, err, delve.NotFound, 0, "b")
+	}()
+	nav.QMust(quals.CQ("b"))
+}
@@ -0,0 +1,105 @@
+package delve_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vloldik/delve/v3"
+)
+
+func TestImmutable(t *testing.T) {
+	newData := func() map[string]any {
+		return map[string]any{
+			"db":    map[string]any{"host": "localhost", "port": 5432},
+			"cache": map[string]any{"ttl": 60},
+			"hosts": []any{"a", "b"},
+		}
+	}
+
+	t.Run("Set returns a new snapshot sharing untouched subtrees", func(t *testing.T) {
+		data := newData()
+		old := delve.NewImmutable(data)
+		updated, ok := old.Set("db.port", 6432)
+		if !ok {
+			t.Fatal("Set failed")
+		}
+		if got := old.Get("db.port").Int(); got != 5432 {
+			t.Errorf("old db.port = %d, want 5432", got)
+		}
+		if got := updated.Get("db.port").Int(); got != 6432 {
+			t.Errorf("new db.port = %d, want 6432", got)
+		}
+		if !reflect.DeepEqual(data, newData()) {
+			t.Errorf("original data was modified: %v", data)
+		}
+		oldCache, _ := old.GetE("cache")
+		newCache, _ := updated.GetE("cache")
+		if reflect.ValueOf(oldCache.Interface()).UnsafePointer() != reflect.ValueOf(newCache.Interface()).UnsafePointer() {
+			t.Error("untouched subtree should be shared")
+		}
+	})
+
+	t.Run("Set creates intermediate maps and appends to lists", func(t *testing.T) {
+		old := delve.NewImmutable(newData())
+		updated, ok := old.Set("feature.flags.beta", true)
+		if !ok {
+			t.Fatal("Set of a new path failed")
+		}
+		updated, ok = updated.Set("hosts.+", "c")
+		if !ok {
+			t.Fatal("Append failed")
+		}
+		if !updated.Get("feature.flags.beta").Bool() || updated.Get("hosts.2").String() != "c" {
+			t.Error("new values should be visible in the new snapshot")
+		}
+		if !old.Get("feature").IsNil() || !old.Get("hosts.2").IsNil() {
+			t.Error("new values should not be visible in the old snapshot")
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		old := delve.NewImmutable(newData())
+		updated, ok := old.Delete("hosts.0")
+		if !ok {
+			t.Fatal("Delete failed")
+		}
+		updated, ok = updated.Delete("db.host")
+		if !ok {
+			t.Fatal("Delete failed")
+		}
+		if got := updated.Get("hosts").Interface(); !reflect.DeepEqual(got, []any{"b"}) {
+			t.Errorf("hosts = %v, want [b]", got)
+		}
+		if !updated.Get("db.host").IsNil() {
+			t.Error("db.host should be removed")
+		}
+		if old.Get("db.host").String() != "localhost" || old.Get("hosts.0").String() != "a" {
+			t.Error("old snapshot should be untouched")
+		}
+		if same, ok := old.Delete("db.missing"); ok || same != old {
+			t.Error("Delete of a missing key should fail and return the receiver")
+		}
+	})
+
+	t.Run("Typed containers and struct pointers", func(t *testing.T) {
+		address := &structAddress{City: "Paris"}
+		old := delve.NewImmutable(map[string]any{"ports": []int{80}, "address": address})
+		updated, ok := old.Set("ports.0", 8080)
+		if !ok {
+			t.Fatal("Set in a typed slice failed")
+		}
+		updated, ok = updated.Set("address.city", "Lyon")
+		if !ok {
+			t.Fatal("Set of a struct field failed")
+		}
+		if address.City != "Paris" || old.Get("ports.0").Int() != 80 {
+			t.Error("old data should be untouched")
+		}
+		if updated.Get("address.city").String() != "Lyon" || updated.Get("ports.0").Int() != 8080 {
+			t.Error("new snapshot should contain changes")
+		}
+		if _, ok := old.Set("ports.0", "x"); ok {
+			t.Error("Set of an incompatible value should fail")
+		}
+	})
+}
@@ -0,0 +1,123 @@
+package delve_test
+
+import (
+	"maps"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vloldik/delve/v3"
+)
+
+func TestIterators(t *testing.T) {
+	nav := delve.New(map[string]any{
+		"list":   []any{1.0, "two", 3},
+		"map":    map[string]any{"a": 1, "b": 2},
+		"ports":  []int{80, 443},
+		"custom": rangeOnlySource{deletableSource{"k": "v"}},
+		"opaque": deletableSource{"k": "v"},
+		"name":   "Alice",
+	})
+
+	// collect drains an iterator of values into a map of their raw values
+	collect := func(seq func(func(string, *delve.Value) bool)) map[string]any {
+		result := map[string]any{}
+		for key, v := range seq {
+			result[key] = v.Interface()
+		}
+		return result
+	}
+
+	t.Run("Navigator", func(t *testing.T) {
+		if got := collect(nav.All()); len(got) != 6 || got["name"] != "Alice" {
+			t.Errorf("All() = %v", got)
+		}
+		for path, want := range map[string]map[string]any{
+			"map":    {"a": 1, "b": 2},
+			"list":   {"0": 1.0, "1": "two", "2": 3},
+			"ports":  {"0": 80, "1": 443},
+			"custom": {"k": "v"},
+			"opaque": {},
+			"name":   {},
+			"none":   {},
+		} {
+			if got := collect(nav.Items(path)); !reflect.DeepEqual(got, want) {
+				t.Errorf("Items(%q) = %v, want %v", path, got, want)
+			}
+		}
+	})
+
+	t.Run("Value items in order with break", func(t *testing.T) {
+		var keys []string
+		for key := range nav.Get("list").Items() {
+			keys = append(keys, key)
+			if key == "1" {
+				break
+			}
+		}
+		if !reflect.DeepEqual(keys, []string{"0", "1"}) {
+			t.Errorf("keys = %v, want [0 1]", keys)
+		}
+		if got := collect(nav.Get("map").Items()); !maps.Equal(got, map[string]any{"a": 1, "b": 2}) {
+			t.Errorf("Items() = %v", got)
+		}
+	})
+
+	t.Run("Typed elements", func(t *testing.T) {
+		got := map[string]int{}
+		for key, n := range delve.Elements[int](nav.Get("list")) {
+			got[key] = n
+		}
+		if want := map[string]int{"0": 1, "1": 0, "2": 3}; !maps.Equal(got, want) {
+			t.Errorf("Elements[int] = %v, want %v", got, want)
+		}
+		var total float64
+		for _, port := range delve.Elements[float64](nav.Get("ports")) {
+			total += port
+		}
+		if total != 523 {
+			t.Errorf("sum of ports = %v, want 523", total)
+		}
+		for range delve.Elements[string](nav.Get("name")) {
+			t.Error("scalar values should yield nothing")
+		}
+	})
+
+	t.Run("Loop body can use a synchronized navigator", func(t *testing.T) {
+		sync := delve.NewSync(map[string]any{"a": 1, "m": map[string]any{"b": 2}})
+		stop := make(chan struct{})
+		defer close(stop)
+		go func() {
+			// a waiting writer blocks new read locks, which deadlocks recursive ones
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					sync.Set("counter", 1)
+				}
+			}
+		}()
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for range 100 {
+				for key := range sync.All() {
+					_ = sync.Get(key)
+				}
+				for key, v := range sync.Items("m") {
+					sync.Set("m."+key, v.Int()+1)
+				}
+			}
+		}()
+		select {
+		case <-done:
+			if got := sync.Get("m.b").Int(); got != 102 {
+				t.Errorf("m.b = %d, want 102", got)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatal("iteration deadlocked")
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package delve_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/vloldik/delve/v3"
+)
+
+const jsonStoreStruct = `{
+	"store": {
+		"book": [
+			{"category": "reference", "author": "Nigel Rees", "title": "Sayings of the Century", "price": 8.95},
+			{"category": "fiction", "author": "Evelyn Waugh", "title": "Sword of Honour", "price": 12.99},
+			{"category": "fiction", "author": "Herman Melville", "title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99},
+			{"category": "fiction", "author": "J. R. R. Tolkien", "title": "The Lord of the Rings", "isbn": "0-395-19395-8", "price": 22.99}
+		],
+		"bicycle": {"color": "red", "price": 19.95}
+	},
+	"expensive": 10
+}`
+
+func storeNavigator(t *testing.T) delve.Navigator {
+	t.Helper()
+	mMap := make(map[string]any)
+	if err := json.Unmarshal([]byte(jsonStoreStruct), &mMap); err != nil {
+		t.Fatal(err)
+	}
+	return delve.New(mMap)
+}
+
+func TestJSONPathQuery(t *testing.T) {
+	nav := storeNavigator(t)
+
+	tests := []struct {
+		expr     string
+		expected []string
+	}{
+		{`$.store.book[0].title`, []string{"store.book.0.title=Sayings of the Century"}},
+		{`$['store']["bicycle"].color`, []string{"store.bicycle.color=red"}},
+		{`$.store.book[-1].author`, []string{"store.book.3.author=J. R. R. Tolkien"}},
+		{`$.store.book[*].price`, []string{"store.book.0.price=8.95", "store.book.1.price=12.99", "store.book.2.price=8.99", "store.book.3.price=22.99"}},
+		{`$.store.book[1:3].price`, []string{"store.book.1.price=12.99", "store.book.2.price=8.99"}},
+		{`$.store.book[::-2].price`, []string{"store.book.1.price=12.99", "store.book.3.price=22.99"}},
+		{`$.store.book[0,2].price`, []string{"store.book.0.price=8.95", "store.book.2.price=8.99"}},
+		{`$..color`, []string{"store.bicycle.color=red"}},
+		{`$.store.*.color`, []string{"store.bicycle.color=red"}},
+		{`$.store.book[?(@.price < 10)].title`, []string{"store.book.0.title=Sayings of the Century", "store.book.2.title=Moby Dick"}},
+		{`$.store.book[?@.isbn].title`, []string{"store.book.2.title=Moby Dick", "store.book.3.title=The Lord of the Rings"}},
+		{`$.store.book[?(!@.isbn)].price`, []string{"store.book.0.price=8.95", "store.book.1.price=12.99"}},
+		{`$.store.book[?(@.category == 'fiction' && (@.price > 20 || @.price < 9))].title`, []string{"store.book.2.title=Moby Dick", "store.book.3.title=The Lord of the Rings"}},
+		{`$.store.book[?(@.price > $.expensive)].price`, []string{"store.book.1.price=12.99", "store.book.3.price=22.99"}},
+		{`$..book[?(@.author != "Nigel Rees" && @.price <= 8.99)].title`, []string{"store.book.2.title=Moby Dick"}},
+		{`$..[?(@.price >= 19.95 && @.color)].color`, []string{"store.bicycle.color=red"}},
+		{`$.missing[*]`, []string{}},
+	}
+
+	for _, test := range tests {
+		query, err := delve.JSONPath(test.expr)
+		if err != nil {
+			t.Errorf("Failed to compile %s: %v", test.expr, err)
+			continue
+		}
+		if got := matchStrings(nav.Query(query)); !slices.Equal(got, test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.expr, test.expected, got)
+		}
+	}
+}
+
+func TestJSONPathMatchPaths(t *testing.T) {
+	nav := storeNavigator(t)
+	for _, m := range nav.Query(delve.MustJSONPath(`$..price`)) {
+		if nav.QGet(m.Path).Float64() != m.Value.Float64() {
+			t.Errorf("Match path %v should point to its value", m.Path)
+		}
+	}
+}
+
+func TestJSONPathCustomSource(t *testing.T) {
+	nav := delve.From(deletableSource{"a": map[string]any{"b": 1}})
+	if got := matchStrings(nav.Query(delve.MustJSONPath(`$.a.b`))); !slices.Equal(got, []string{"a.b=1"}) {
+		t.Errorf("Unexpected matches %v", got)
+	}
+}
+
+func TestJSONPathIndexOnlyOnLists(t *testing.T) {
+	nav := delve.New(map[string]any{
+		"ids":    map[int]string{0: "zero"},
+		"any":    map[any]any{"0": "zero"},
+		"struct": &struct{ Zero string `json:"0"` }{"zero"},
+		"ports":  []int{80, 443},
+	})
+	for expr, expected := range map[string][]string{
+		`$.ids[0]`:    {},
+		`$.any[0]`:    {},
+		`$.struct[0]`: {},
+		`$.ids['0']`:  {"ids.0=zero"},
+		`$.ports[-1]`: {"ports.1=443"},
+	} {
+		if got := matchStrings(nav.Query(delve.MustJSONPath(expr))); !slices.Equal(got, expected) {
+			t.Errorf("%s: expected %v, got %v", expr, expected, got)
+		}
+	}
+}
+
+func TestJSONPathInvalid(t *testing.T) {
+	for _, expr := range []string{``, `store`, `$.`, `$[`, `$['a`, `$[?(@.a <)]`, `$[?(@.a == 1]`, `$.a b`} {
+		if _, err := delve.JSONPath(expr); err == nil {
+			t.Errorf("Expected error for %q", expr)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package delve_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vloldik/delve/v3"
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
+
+// rangeOnlySource is a deletableSource which can be enumerated, but doesn't report its length
+type rangeOnlySource struct {
+	deletableSource
+}
+
+func (rs rangeOnlySource) Range(f func(key string, v any) bool) {
+	for k, v := range rs.deletableSource {
+		if !f(k, v) {
+			return
+		}
+	}
+}
+
+func TestKeys(t *testing.T) {
+	nav := delve.New(map[string]any{
+		"map":    map[string]any{"b": 1, "a": nil},
+		"list":   make([]any, 11),
+		"ranger": rangeOnlySource{deletableSource{"y": 1, "x": 2}},
+		"opaque": deletableSource{"k": 1},
+		"name":   "Alice",
+		"user":   &structAddress{City: "Paris"},
+	})
+
+	t.Run("Has", func(t *testing.T) {
+		for path, want := range map[string]bool{
+			"map.a": true, "map.c": false, "list.10": true, "list.-1": true, "list.11": false,
+			"ranger.x": true, "opaque.k": true, "opaque.z": false, "name.first": false,
+		} {
+			if got := nav.Has(path); got != want {
+				t.Errorf("Has(%q) = %v, want %v", path, got, want)
+			}
+		}
+	})
+
+	t.Run("Keys", func(t *testing.T) {
+		for path, want := range map[string][]string{
+			"map":    {"a", "b"},
+			"list":   {"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10"},
+			"ranger": {"x", "y"},
+			"":       {"list", "map", "name", "opaque", "ranger", "user"},
+			"opaque": nil,
+			"name":   nil,
+			"none":   nil,
+		} {
+			if got := nav.Keys(path); !reflect.DeepEqual(got, want) {
+				t.Errorf("Keys(%q) = %v, want %v", path, got, want)
+			}
+		}
+		if keys := nav.Keys("user"); len(keys) == 0 {
+			t.Error("struct fields should be enumerated")
+		}
+		if keys := delve.New(map[string]any{"empty": map[string]any{}}).Keys("empty"); keys == nil || len(keys) != 0 {
+			t.Errorf("keys of an empty map = %#v, want empty non-nil slice", keys)
+		}
+	})
+
+	t.Run("Len", func(t *testing.T) {
+		for path, want := range map[string]int{
+			"map": 2, "list": 11, "ranger": 2, "": 6, "name": 5, "opaque": 1, "none": -1, "map.b": -1,
+		} {
+			if got := nav.Len(path); got != want {
+				t.Errorf("Len(%q) = %d, want %d", path, got, want)
+			}
+		}
+		for _, source := range []idelve.ISource{delve.New(map[string]any{}).Source(), delve.New([]any{}).Source()} {
+			if _, ok := source.(idelve.IRanger); !ok {
+				t.Errorf("%T should implement idelve.IRanger", source)
+			}
+		}
+		if got := delve.From(rangeOnlySource{deletableSource{"a": 1}}).QLen(nil); got != 1 {
+			t.Errorf("QLen(nil) of a custom source = %d, want 1", got)
+		}
+	})
+}
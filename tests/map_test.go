@@ -2,6 +2,7 @@ package delve_test
 
 import (
 	"encoding/json"
+	"reflect"
 	"testing"
 
 	"github.com/vloldik/delve/v3"
@@ -270,3 +271,80 @@ func TestTypeDefaults(t *testing.T) {
 		t.Errorf("safe interface default not equal")
 	}
 }
+
+func TestCollectionGets(t *testing.T) {
+	mMap := make(map[string]any)
+	err := json.Unmarshal([]byte(`{
+		"strings": ["a", "b"],
+		"numbers": [1, 2.0, 3],
+		"fractions": [1, 2.5],
+		"flags": [true, false],
+		"mixed": ["a", 1],
+		"empty": [],
+		"limits": {"cpu": 1.5, "memory": 512},
+		"labels": {"env": "prod"},
+		"nested": {"a": {"b": 1}}
+	}`), &mMap)
+	if err != nil {
+		panic(err)
+	}
+	nav := delve.New(mMap)
+
+	if got := nav.Get("strings").StringSlice(); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("StringSlice = %v", got)
+	}
+	if got := nav.Get("numbers").IntSlice(); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("IntSlice = %v", got)
+	}
+	if got := nav.Get("numbers").Int64Slice(); !reflect.DeepEqual(got, []int64{1, 2, 3}) {
+		t.Errorf("Int64Slice = %v", got)
+	}
+	if got := nav.Get("fractions").Float64Slice(); !reflect.DeepEqual(got, []float64{1, 2.5}) {
+		t.Errorf("Float64Slice = %v", got)
+	}
+	if got := nav.Get("flags").BoolSlice(); !reflect.DeepEqual(got, []bool{true, false}) {
+		t.Errorf("BoolSlice = %v", got)
+	}
+	if got := nav.Get("empty").StringSlice(); got == nil || len(got) != 0 {
+		t.Errorf("StringSlice of an empty list = %#v, want empty slice", got)
+	}
+	if got := nav.Get("fractions").IntSlice([]int{-1}); !reflect.DeepEqual(got, []int{-1}) {
+		t.Errorf("lossy element should return the default, got %v", got)
+	}
+	if got := nav.Get("mixed").StringSlice(); got != nil {
+		t.Errorf("mismatched element should return nil, got %v", got)
+	}
+
+	if got := nav.Get("limits").Float64Map(); !reflect.DeepEqual(got, map[string]float64{"cpu": 1.5, "memory": 512}) {
+		t.Errorf("Float64Map = %v", got)
+	}
+	if got := nav.Get("labels").StringMap(); !reflect.DeepEqual(got, map[string]string{"env": "prod"}) {
+		t.Errorf("StringMap = %v", got)
+	}
+	if got := nav.Get("limits").IntMap(map[string]int{}); len(got) != 0 {
+		t.Errorf("lossy value should return the default, got %v", got)
+	}
+	if got := nav.Get("strings").StringMap(); got != nil {
+		t.Errorf("StringMap of a list should be nil, got %v", got)
+	}
+
+	typed := delve.New(map[string]any{"ports": []int{80, 443}, "array": [2]uint8{1, 2}, "counts": map[string]int{"a": 1}})
+	if got := delve.SliceOf[uint16](typed.Get("ports")); !reflect.DeepEqual(got, []uint16{80, 443}) {
+		t.Errorf("SliceOf[uint16] = %v", got)
+	}
+	if got := delve.SliceOf[int](typed.Get("array")); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("SliceOf[int] of an array = %v", got)
+	}
+	if got := delve.SliceOf[uint8](typed.Get("ports"), []uint8{0}); !reflect.DeepEqual(got, []uint8{0}) {
+		t.Errorf("SliceOf[uint8] overflow should return the default, got %v", got)
+	}
+	if got := delve.MapOf[float32](typed.Get("counts")); !reflect.DeepEqual(got, map[string]float32{"a": 1}) {
+		t.Errorf("MapOf[float32] = %v", got)
+	}
+	if got := delve.MapOf[map[string]any](nav.Get("nested")); got["a"]["b"] != 1.0 {
+		t.Errorf("MapOf[map[string]any] = %v", got)
+	}
+	if got := delve.SliceOf[any](nav.Get("mixed")); len(got) != 2 {
+		t.Errorf("SliceOf[any] = %v", got)
+	}
+}
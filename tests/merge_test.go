@@ -0,0 +1,140 @@
+package delve_test
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vloldik/delve/v3"
+)
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name, doc, patch, expected string
+	}{
+		{"replace value", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"add value", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"delete value", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{"replace list", `{"a":["b"]}`, `{"a":["c","d"]}`, `{"a":["c","d"]}`},
+		{"replace list with map", `{"a":["b"]}`, `{"a":{"b":"c"}}`, `{"a":{"b":"c"}}`},
+		{"nested merge", `{"a":{"b":"c","d":"e"}}`, `{"a":{"b":"x","d":null}}`, `{"a":{"b":"x"}}`},
+		{"nulls in new map are dropped", `{"e":null}`, `{"a":{"bb":{"ccc":null}}}`, `{"e":null,"a":{"bb":{}}}`},
+		{"scalar replaced by map", `{"a":"foo"}`, `{"a":{"b":1}}`, `{"a":{"b":1}}`},
+		{"delete missing key", `{"a":1}`, `{"b":null}`, `{"a":1}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nav := delve.New(jsonMap(t, test.doc))
+			if err := nav.MergePatch(jsonMap(t, test.patch)); err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			if got := toJSONValue(t, nav.Source()); !reflect.DeepEqual(got, jsonMap(t, test.expected)) {
+				t.Errorf("Expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestMergePatchReplacesListRoot(t *testing.T) {
+	nav := delve.New([]any{1, 2})
+	if err := nav.MergePatch(map[string]any{"a": 1}); err != nil {
+		t.Fatal(err)
+	}
+	if nav.Get("a").Int() != 1 {
+		t.Error("List root should be replaced with a map")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	const base = `{"name":"base","db":{"host":"localhost","port":5432},"tags":["a","b"],
+		"users":[{"name":"alice","role":"admin"},{"name":"bob","role":"user"}]}`
+	const override = `{"name":"override","db":{"port":6432,"user":"app"},"tags":["c"],
+		"users":[{"name":"bob","role":"admin"},{"name":"carol"}]}`
+
+	tests := []struct {
+		name     string
+		opts     delve.MergeOptions
+		expected string
+	}{
+		{"defaults", delve.MergeOptions{},
+			`{"name":"override","db":{"host":"localhost","port":6432,"user":"app"},"tags":["c"],
+			"users":[{"name":"bob","role":"admin"},{"name":"carol"}]}`},
+		{"append and keep", delve.MergeOptions{Lists: delve.ListAppend, Conflicts: delve.ConflictKeep},
+			`{"name":"base","db":{"host":"localhost","port":5432,"user":"app"},"tags":["a","b","c"],
+			"users":[{"name":"alice","role":"admin"},{"name":"bob","role":"user"},{"name":"bob","role":"admin"},{"name":"carol"}]}`},
+		{"merge by index", delve.MergeOptions{Lists: delve.ListMergeByIndex},
+			`{"name":"override","db":{"host":"localhost","port":6432,"user":"app"},"tags":["c","b"],
+			"users":[{"name":"bob","role":"admin"},{"name":"carol","role":"user"}]}`},
+		{"merge by key", delve.MergeOptions{Lists: delve.ListMergeByKey, ListKey: "name"},
+			`{"name":"override","db":{"host":"localhost","port":6432,"user":"app"},"tags":["a","b","c"],
+			"users":[{"name":"alice","role":"admin"},{"name":"bob","role":"admin"},{"name":"carol"}]}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nav := delve.New(jsonMap(t, base))
+			other := delve.New(jsonMap(t, override))
+			if err := nav.Merge(other, test.opts); err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			if got := toJSONValue(t, nav.Source()); !reflect.DeepEqual(got, jsonMap(t, test.expected)) {
+				t.Errorf("Expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestMergeDoesNotShareData(t *testing.T) {
+	nav := delve.New(map[string]any{})
+	other := delve.New(map[string]any{"a": map[string]any{"b": []any{1}}})
+	if err := nav.Merge(other); err != nil {
+		t.Fatal(err)
+	}
+	nav.Set("a.b.+", 2)
+	nav.Set("a.c", 3)
+	if other.Get("a.b").Len() != 1 || other.Get("a.c").Interface() != nil {
+		t.Error("Merged data should be copied")
+	}
+}
+
+func TestMergeConflictError(t *testing.T) {
+	nav := delve.New(map[string]any{"a": 1, "b": map[string]any{"c": "x"}, "d": float64(2)})
+	other := delve.New(map[string]any{"a": 2, "b": map[string]any{"c": "y"}, "d": 2, "e": 3})
+
+	err := nav.Merge(other, delve.MergeOptions{Conflicts: delve.ConflictError})
+	var conflictErr *delve.MergeConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("Expected *delve.MergeConflictError, got %v", err)
+	}
+	if nav.Get("a").Int() != 1 || nav.Get("b.c").String() != "x" || nav.Get("e").Interface() != nil {
+		t.Error("Data should be untouched after a failed merge")
+	}
+
+	other.Set("a", 1)
+	other.Set("b.c", "x")
+	if err := nav.Merge(other, delve.MergeOptions{Conflicts: delve.ConflictError}); err != nil {
+		t.Errorf("Equal values (including numbers of different types) should not conflict, got %v", err)
+	}
+}
+
+func TestMergeScalarStructs(t *testing.T) {
+	old, updated := time.Unix(0, 0), time.Unix(1000, 0)
+	nav := delve.New(map[string]any{"t": old, "addr": netip.MustParseAddr("10.0.0.1")})
+	if err := nav.Merge(delve.New(map[string]any{"t": updated, "addr": netip.MustParseAddr("10.0.0.2")})); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if got := nav.Get("t").Interface(); got != updated {
+		t.Errorf("Expected the time to be overwritten, got %v", got)
+	}
+	if got := nav.Get("addr").Interface(); got != netip.MustParseAddr("10.0.0.2") {
+		t.Errorf("Expected the address to be overwritten, got %v", got)
+	}
+
+	var conflict *delve.MergeConflictError
+	err := nav.Merge(delve.New(map[string]any{"t": old}), delve.MergeOptions{Conflicts: delve.ConflictError})
+	if !errors.As(err, &conflict) || fmt.Sprint(conflict.Path) != "t" {
+		t.Errorf("Expected a conflict at t, got %v", err)
+	}
+}
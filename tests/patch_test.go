@@ -0,0 +1,117 @@
+package delve_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/vloldik/delve/v3"
+)
+
+func jsonMap(t *testing.T, s string) map[string]any {
+	t.Helper()
+	m := map[string]any{}
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func jsonPatch(t *testing.T, s string) []delve.PatchOperation {
+	t.Helper()
+	var ops []delve.PatchOperation
+	if err := json.Unmarshal([]byte(s), &ops); err != nil {
+		t.Fatal(err)
+	}
+	return ops
+}
+
+func TestApplyPatch(t *testing.T) {
+	tests := []struct {
+		name, doc, patch, expected string
+	}{
+		{"add object member", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"foo":"bar","baz":"qux"}`},
+		{"add array element", `{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{"append array element", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":"qux"}]`, `{"foo":["bar","qux"]}`},
+		{"add nested member", `{"foo":{"bar":1}}`, `[{"op":"add","path":"/foo/baz","value":{"a":[1]}}]`, `{"foo":{"bar":1,"baz":{"a":[1]}}}`},
+		{"remove object member", `{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{"remove array element", `{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{"replace value", `{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{"move value", `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{"move array element", `{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+		{"copy value", `{"a":{"b":[1]}}`, `[{"op":"copy","from":"/a","path":"/c"},{"op":"add","path":"/c/b/-","value":2}]`, `{"a":{"b":[1]},"c":{"b":[1,2]}}`},
+		{"test and add", `{"baz":"qux","foo":["a",2,"c"]}`, `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`, `{"baz":"qux","foo":["a",2,"c"]}`},
+		{"escaped keys", `{"a/b":{"m~n":1}}`, `[{"op":"replace","path":"/a~1b/m~0n","value":2}]`, `{"a/b":{"m~n":2}}`},
+		{"replace root", `{"a":1}`, `[{"op":"replace","path":"","value":{"b":2}}]`, `{"b":2}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nav := delve.New(jsonMap(t, test.doc))
+			if err := nav.ApplyPatch(jsonPatch(t, test.patch)); err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			expected := jsonMap(t, test.expected)
+			if got := nav.Source(); !reflect.DeepEqual(toJSONValue(t, got), expected) {
+				t.Errorf("Expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
+
+// toJSONValue normalizes data by a json round trip
+func toJSONValue(t *testing.T, v any) any {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result any
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestApplyPatchIsAtomic(t *testing.T) {
+	tests := []struct {
+		name, patch string
+		failedIndex int
+	}{
+		{"failed test", `[{"op":"add","path":"/x","value":1},{"op":"remove","path":"/list/0"},{"op":"test","path":"/a/b","value":"wrong"}]`, 2},
+		{"missing path", `[{"op":"replace","path":"/a/b","value":2},{"op":"add","path":"/list/-","value":3},{"op":"remove","path":"/missing"}]`, 2},
+		{"index out of range", `[{"op":"move","from":"/list/0","path":"/a/moved"},{"op":"add","path":"/list/5","value":1}]`, 1},
+		{"missing parent", `[{"op":"copy","from":"/a","path":"/list/0"},{"op":"add","path":"/x/y","value":1}]`, 1},
+		{"unknown op", `[{"op":"add","path":"","value":{}},{"op":"unknown","path":"/a"}]`, 1},
+	}
+
+	const doc = `{"a":{"b":1},"list":[1,2]}`
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nav := delve.New(jsonMap(t, doc))
+			err := nav.ApplyPatch(jsonPatch(t, test.patch))
+			var patchErr *delve.PatchError
+			if !errors.As(err, &patchErr) {
+				t.Fatalf("Expected *delve.PatchError, got %v", err)
+			}
+			if patchErr.Index != test.failedIndex {
+				t.Errorf("Expected failed index %d, got %d", test.failedIndex, patchErr.Index)
+			}
+			if got := toJSONValue(t, nav.Source()); !reflect.DeepEqual(got, jsonMap(t, doc)) {
+				t.Errorf("Data should be untouched, got %v", got)
+			}
+		})
+	}
+}
+
+func TestApplyPatchTestFailed(t *testing.T) {
+	nav := delve.New(map[string]any{"a": 1})
+	err := nav.ApplyPatch([]delve.PatchOperation{{Op: "test", Path: "/a", Value: 2}})
+	if !errors.Is(err, delve.ErrTestFailed) {
+		t.Errorf("Expected ErrTestFailed, got %v", err)
+	}
+	if err := nav.ApplyPatch([]delve.PatchOperation{{Op: "test", Path: "/a", Value: float64(1)}}); err != nil {
+		t.Errorf("Numbers of different types should be equal, got %v", err)
+	}
+}
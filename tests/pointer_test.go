@@ -0,0 +1,87 @@
+package delve_test
+
+import (
+	"testing"
+
+	"github.com/vloldik/delve/v3"
+	"github.com/vloldik/delve/v3/internal/quals"
+)
+
+func TestPointerQual(t *testing.T) {
+	IQualTest(t, quals.Pointer("/a/b~1c/m~0n/0"), []string{"a", "b/c", "m~n", "0"})
+	IQualTest(t, quals.Pointer("/"), []string{""})
+	IQualTest(t, quals.Pointer("/a/-"), []string{"a", "-"})
+	IQualTest(t, quals.Pointer("/~01"), []string{"~1"})
+}
+
+func TestPointerInvalid(t *testing.T) {
+	for _, pointer := range []string{"a/b", "/a~", "/a~2"} {
+		if _, err := delve.ParsePointer(pointer); err == nil {
+			t.Errorf("Expected error for %q", pointer)
+		}
+	}
+}
+
+func TestPointerNavigation(t *testing.T) {
+	m := map[string]any{
+		"a":   map[string]any{"b/c": []any{1, 2}},
+		"m~n": 3,
+	}
+	nav := delve.New(m)
+	if v := nav.QGet(delve.Pointer("/a/b~1c/1")).Int(); v != 2 {
+		t.Errorf("Expected 2, got %v", v)
+	}
+	if v := nav.QGet(delve.Pointer("/m~0n")).Int(); v != 3 {
+		t.Errorf("Expected 3, got %v", v)
+	}
+	if !nav.QSet(delve.Pointer("/a/b~1c/-"), 4) {
+		t.Fatal("Append failed")
+	}
+	if v := nav.QGet(delve.Pointer("/a/b~1c/2")).Int(); v != 4 {
+		t.Errorf("Expected 4, got %v", v)
+	}
+}
+
+func TestPointerDashKey(t *testing.T) {
+	nav := delve.New(map[string]any{"-": 1, "+": 2, "list": []any{}})
+	if v := nav.QGet(delve.Pointer("/-")).Int(); v != 1 {
+		t.Errorf("Expected 1, got %v", v)
+	}
+	if !nav.QSet(delve.Pointer("/m/-"), 3) || nav.Get("m.-").Int() != 3 || nav.Has("m.+") {
+		t.Errorf("Expected key \"-\" in a new map, got %v", nav.Get("m").Interface())
+	}
+	if err := nav.ApplyPatch([]delve.PatchOperation{
+		{Op: "add", Path: "/-", Value: 4},
+		{Op: "add", Path: "/list/-", Value: 5},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if nav.Get("-").Int() != 4 || nav.Get("+").Int() != 2 || nav.Get("list.0").Int() != 5 {
+		t.Errorf("Unexpected data after patch: %v", nav.Source())
+	}
+	if got := delve.ToPointer(delve.CQ("+")); got != "/+" {
+		t.Errorf("Expected \"/+\", got %q", got)
+	}
+}
+
+func TestToPointer(t *testing.T) {
+	tests := map[string]string{
+		"a.b/c.0": "/a/b~1c/0",
+		"m~n.+":   "/m~0n/+",
+		"l.-":     "/l/-",
+		"a\\.b":   "/a.b",
+	}
+	for path, expected := range tests {
+		if got := delve.ToPointer(delve.CQ(path)); got != expected {
+			t.Errorf("CQ(%q): expected %q, got %q", path, expected, got)
+		}
+		if got := delve.ToPointer(delve.Q(path)); got != expected {
+			t.Errorf("Q(%q): expected %q, got %q", path, expected, got)
+		}
+	}
+	for _, pointer := range []string{"", "/", "/a/b~1c/-", "/~0/~1"} {
+		if got := delve.ToPointer(delve.Pointer(pointer)); got != pointer {
+			t.Errorf("Expected %q to round-trip, got %q", pointer, got)
+		}
+	}
+}
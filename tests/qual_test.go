@@ -2,8 +2,10 @@ package delve_test
 
 import (
 	"slices"
+	"sync"
 	"testing"
 
+	"github.com/vloldik/delve/v3"
 	"github.com/vloldik/delve/v3/internal/quals"
 	"github.com/vloldik/delve/v3/pkg/idelve"
 )
@@ -55,6 +57,21 @@ func IQualTest(t *testing.T, qual idelve.IQual, expectedParts []string) {
 	if hasNext {
 		t.Fatalf("After iteration is complete, hasNext should be false, but equals: %#v", hasNext)
 	}
+
+	// Stateless iteration must return the same parts regardless of the Next() state
+	if stateless, ok := qual.(idelve.IStatelessQual); ok {
+		statelessParts := []string{}
+		for pos, hasNext := 0, true; hasNext; {
+			part, _, pos, hasNext = stateless.SegmentAt(pos)
+			statelessParts = append(statelessParts, part)
+			if len(statelessParts) > len(expectedParts) {
+				t.Fatalf("Too many segments of SegmentAt(), expected %d", len(expectedParts))
+			}
+		}
+		if !slices.Equal(statelessParts, expectedParts) {
+			t.Fatalf("Stateless parts %#v not equal expected %#v", statelessParts, expectedParts)
+		}
+	}
 }
 
 func TestStringQual(t *testing.T) {
@@ -164,3 +181,49 @@ func TestCompiledQualConsecutiveEscapedBackslashes(t *testing.T) {
 	expected := []string{"a\\\\", "b"}
 	IQualTest(t, quals.CQ(qual), expected)
 }
+
+var sharedCompiledQual = quals.CQ("a.b\\.c.0")
+var sharedStringQual = quals.Q("a.b\\.c.0")
+
+func TestSharedQualsConcurrently(t *testing.T) {
+	nav := delve.New(map[string]any{"a": map[string]any{"b.c": []any{42}}})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				if got := nav.QGet(sharedCompiledQual).Int(); got != 42 {
+					t.Errorf("compiled qual: got %d, want 42", got)
+					return
+				}
+				if got := nav.QGet(sharedStringQual).Int(); got != 42 {
+					t.Errorf("string qual: got %d, want 42", got)
+					return
+				}
+				_ = delve.ToPointer(sharedStringQual)
+				_ = nav.QGetAll(sharedStringQual)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestStatelessQualDoesNotAllocate(t *testing.T) {
+	nav := delve.New(map[string]any{"a": map[string]any{"b": 1}})
+	compiled, str := quals.CQ("a.b"), quals.Q("a.b")
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = nav.QGetRaw(compiled)
+		_, _ = nav.QGetRaw(str)
+	})
+	if allocs != 0 {
+		t.Errorf("QGetRaw allocates %v times per run", allocs)
+	}
+	allocs = testing.AllocsPerRun(100, func() {
+		_ = nav.QGet(compiled).Int()
+		_ = nav.QGet(str).Int()
+	})
+	if allocs != 0 {
+		t.Errorf("QGet allocates %v times per run", allocs)
+	}
+}
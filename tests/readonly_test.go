@@ -0,0 +1,88 @@
+package delve_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/vloldik/delve/v3"
+)
+
+func TestReadOnly(t *testing.T) {
+	newData := func() map[string]any {
+		return map[string]any{
+			"plugins": map[string]any{"cache": map[string]any{"ttl": 60}},
+			"hosts":   []any{"a"},
+		}
+	}
+
+	t.Run("Writes fail", func(t *testing.T) {
+		data := newData()
+		view := delve.New(data).ReadOnly()
+		if !view.IsReadOnly() {
+			t.Error("view should be read-only")
+		}
+		if view.Set("plugins.cache.ttl", 1) || view.Set("new", 1) || view.Delete("hosts.0") {
+			t.Error("Set and Delete should fail")
+		}
+		var pathErr *delve.PathError
+		if err := view.SetE("plugins.cache", 1); !errors.As(err, &pathErr) || pathErr.Reason != delve.ReadOnly || pathErr.Segment != "plugins" {
+			t.Errorf("SetE error = %v, want ReadOnly at \"plugins\"", err)
+		}
+		if err := view.ApplyPatch([]delve.PatchOperation{{Op: "remove", Path: "/hosts/0"}}); !errors.As(err, &pathErr) || pathErr.Reason != delve.ReadOnly {
+			t.Errorf("ApplyPatch error = %v, want ReadOnly", err)
+		}
+		if err := view.MergePatch(map[string]any{"hosts": nil}); !errors.As(err, &pathErr) || pathErr.Reason != delve.ReadOnly {
+			t.Errorf("MergePatch error = %v, want ReadOnly", err)
+		}
+		if err := view.Merge(delve.New(map[string]any{"x": 1})); !errors.As(err, &pathErr) || pathErr.Reason != delve.ReadOnly {
+			t.Errorf("Merge error = %v, want ReadOnly", err)
+		}
+		view.SetMapSource(map[string]any{})
+		view.SetListSource([]any{})
+		if err := view.Update(func(tx delve.Navigator) error {
+			if tx.Set("hosts.0", "b") {
+				t.Error("Set in a transaction of a read-only view should fail")
+			}
+			return nil
+		}); err != nil {
+			t.Errorf("Update error = %v", err)
+		}
+		if !reflect.DeepEqual(data, newData()) || !reflect.DeepEqual(view.Source(), delve.New(newData()).Source()) {
+			t.Errorf("data was modified through the view: %v", data)
+		}
+	})
+
+	t.Run("Sub-navigators are read-only", func(t *testing.T) {
+		data := newData()
+		cache := delve.New(data).ReadOnly().GetNavigator("plugins").GetNavigator("cache")
+		if !cache.IsReadOnly() || cache.Set("ttl", 1) || cache.Delete("ttl") {
+			t.Error("sub-navigator of a read-only view should be read-only")
+		}
+		if data["plugins"].(map[string]any)["cache"].(map[string]any)["ttl"] != 60 {
+			t.Error("raw map was modified through a sub-navigator")
+		}
+		if hosts := delve.New(data).ReadOnly().GetNavigator("hosts"); hosts.Set("+", "b") {
+			t.Error("append through a read-only list sub-navigator should fail")
+		}
+	})
+
+	t.Run("View sees changes of the original", func(t *testing.T) {
+		nav := delve.NewSync(newData())
+		view := nav.ReadOnly()
+		var changed int
+		view.Watch("plugins.cache.ttl", func(old, new *delve.Value) {
+			changed = new.Int()
+		})
+		nav.Set("plugins.cache.ttl", 30)
+		if view.Get("plugins.cache.ttl").Int() != 30 || changed != 30 {
+			t.Error("changes of the original should be visible through the view")
+		}
+		if nav.IsReadOnly() || !nav.Set("hosts.0", "b") {
+			t.Error("original navigator should stay writable")
+		}
+		if clone := view.Clone(); !clone.Set("hosts.0", "c") || nav.Get("hosts.0").String() != "b" {
+			t.Error("clone of a view should be an independent writable copy")
+		}
+	})
+}
@@ -133,4 +133,78 @@ func TestSetFunction(t *testing.T) {
 			t.Errorf("Expected 'value', got %v", elem0["key"])
 		}
 	})
+
+	t.Run("Append to nested list in map", func(t *testing.T) {
+		m := map[string]any{"user": map[string]any{"roles": []any{"admin"}}}
+		nav := delve.New(m)
+		for _, role := range []string{"editor", "viewer", "guest"} {
+			if !nav.QSet(quals.CQ("user.roles.+"), role) {
+				t.Fatal("QualSet failed")
+			}
+		}
+		roles := m["user"].(map[string]any)["roles"].([]any)
+		if len(roles) != 4 || roles[3] != "guest" {
+			t.Errorf("Expected 4 roles ending with guest, got %v", roles)
+		}
+	})
+
+	t.Run("Append to maps in lists in maps", func(t *testing.T) {
+		m := map[string]any{
+			"groups": []any{
+				map[string]any{"members": []any{}},
+				map[string]any{"members": []any{"bob"}},
+			},
+		}
+		nav := delve.New(m)
+		if !nav.Set("groups.0.members.+", "alice") || !nav.Set("groups.-1.members.+", "carol") {
+			t.Fatal("Set failed")
+		}
+		groups := m["groups"].([]any)
+		first := groups[0].(map[string]any)["members"].([]any)
+		last := groups[1].(map[string]any)["members"].([]any)
+		if len(first) != 1 || first[0] != "alice" {
+			t.Errorf("Expected [alice], got %v", first)
+		}
+		if len(last) != 2 || last[1] != "carol" {
+			t.Errorf("Expected [bob carol], got %v", last)
+		}
+	})
+
+	t.Run("Append new map to nested list", func(t *testing.T) {
+		m := map[string]any{"items": []any{}}
+		nav := delve.New(m)
+		if !nav.Set("items.+.name", "first") {
+			t.Fatal("Set failed")
+		}
+		items := m["items"].([]any)
+		if len(items) != 1 {
+			t.Fatalf("Expected one item, got %v", items)
+		}
+		if item, ok := items[0].(map[string]any); !ok || item["name"] != "first" {
+			t.Errorf("Expected map with name first, got %#v", items[0])
+		}
+	})
+
+	t.Run("Append to list in list", func(t *testing.T) {
+		list := []any{[]any{1}}
+		nav := delve.New(list)
+		if !nav.Set("0.+", 2) {
+			t.Fatal("Set failed")
+		}
+		if val := nav.Get("0.1").Int(); val != 2 {
+			t.Errorf("Expected 2, got %v", val)
+		}
+		if inner := list[0].([]any); len(inner) != 2 {
+			t.Errorf("Expected inner list of length 2, got %v", inner)
+		}
+	})
+
+	t.Run("Created intermediate maps are plain maps", func(t *testing.T) {
+		m := map[string]any{}
+		nav := delve.New(m)
+		nav.Set("a.b", 1)
+		if _, ok := m["a"].(map[string]any); !ok {
+			t.Errorf("Expected map[string]any, got %T", m["a"])
+		}
+	})
 }
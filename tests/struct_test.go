@@ -0,0 +1,174 @@
+package delve_test
+
+import (
+	"testing"
+
+	"github.com/vloldik/delve/v3"
+)
+
+type structAddress struct {
+	City string `json:"city"`
+	Zip  int    `delve:"zip" json:"postal_code"`
+}
+
+type structMeta struct {
+	Version int
+	Owner   *string
+}
+
+type structUser struct {
+	structMeta
+	Name     string         `json:"name,omitempty"`
+	Age      uint8          `json:"age"`
+	Score    float64        `json:"score"`
+	Address  structAddress  `json:"address"`
+	Previous *structAddress `json:"previous"`
+	Tags     []any          `json:"tags"`
+	Extra    map[string]any `json:"extra"`
+	Secret   string         `json:"-"`
+	internal int
+}
+
+func newStructUser() *structUser {
+	return &structUser{
+		structMeta: structMeta{Version: 2},
+		Name:       "Ann",
+		Age:        30,
+		Address:    structAddress{City: "Paris", Zip: 75000},
+		Previous:   &structAddress{City: "Lyon"},
+		Tags:       []any{"a", "b"},
+		Extra:      map[string]any{"k": "v"},
+		Secret:     "hidden",
+		internal:   1,
+	}
+}
+
+func TestStructSource(t *testing.T) {
+	t.Run("Get by tag and field name", func(t *testing.T) {
+		nav := delve.New(map[string]any{"user": newStructUser()})
+		if got := nav.Get("user.name").String(); got != "Ann" {
+			t.Errorf("user.name = %q, want Ann", got)
+		}
+		if got := nav.Get("user.Name").String(); got != "Ann" {
+			t.Errorf("user.Name = %q, want Ann", got)
+		}
+		if got := nav.Get("user.address.zip").Int(); got != 75000 {
+			t.Errorf("delve tag should have priority over json tag, got %d", got)
+		}
+		if !nav.Get("user.address.postal_code").IsNil() {
+			t.Error("json tag should be ignored when delve tag is present")
+		}
+	})
+
+	t.Run("Skipped and unexported fields", func(t *testing.T) {
+		nav := delve.New(map[string]any{"user": newStructUser()})
+		for _, path := range []string{"user.Secret", "user.internal", "user.-"} {
+			if !nav.Get(path).IsNil() {
+				t.Errorf("%s should not be accessible", path)
+			}
+		}
+	})
+
+	t.Run("Embedded fields, pointers and nested containers", func(t *testing.T) {
+		nav := delve.New(map[string]any{"user": newStructUser()})
+		if got := nav.Get("user.Version").Int(); got != 2 {
+			t.Errorf("promoted user.Version = %d, want 2", got)
+		}
+		if got := nav.Get("user.previous.city").String(); got != "Lyon" {
+			t.Errorf("user.previous.city = %q, want Lyon", got)
+		}
+		if got := nav.Get("user.tags.1").String(); got != "b" {
+			t.Errorf("user.tags.1 = %q, want b", got)
+		}
+		if got := nav.Get("user.extra.k").String(); got != "v" {
+			t.Errorf("user.extra.k = %q, want v", got)
+		}
+	})
+
+	t.Run("Set through pointer with conversion", func(t *testing.T) {
+		user := newStructUser()
+		nav := delve.New(map[string]any{"user": user})
+		if !nav.Set("user.age", 31) {
+			t.Fatal("Set of user.age failed")
+		}
+		if !nav.Set("user.score", 7) {
+			t.Fatal("Set of user.score failed")
+		}
+		if !nav.Set("user.address.city", "Berlin") {
+			t.Fatal("Set of nested struct field failed")
+		}
+		if !nav.Set("user.previous.zip", int64(69000)) {
+			t.Fatal("Set through struct pointer failed")
+		}
+		if !nav.Set("user.Version", 3) {
+			t.Fatal("Set of promoted field failed")
+		}
+		if user.Age != 31 || user.Score != 7 || user.Address.City != "Berlin" || user.Previous.Zip != 69000 || user.Version != 3 {
+			t.Errorf("unexpected struct state: %+v", user)
+		}
+	})
+
+	t.Run("Set rejects lossy or mismatched values", func(t *testing.T) {
+		user := newStructUser()
+		nav := delve.New(map[string]any{"user": user})
+		if nav.Set("user.age", 300) {
+			t.Error("300 should not fit into uint8")
+		}
+		if nav.Set("user.age", 1.5) {
+			t.Error("1.5 should not be converted to uint8")
+		}
+		if nav.Set("user.name", 1) {
+			t.Error("int should not be set to a string field")
+		}
+		if nav.Set("user.missing", 1) {
+			t.Error("Set of a missing field should fail")
+		}
+		if user.Age != 30 || user.Name != "Ann" {
+			t.Errorf("struct should be untouched: %+v", user)
+		}
+	})
+
+	t.Run("Nested containers of struct can be appended", func(t *testing.T) {
+		user := newStructUser()
+		nav := delve.New(map[string]any{"user": user})
+		if !nav.Set("user.tags.+", "c") {
+			t.Fatal("Append to struct slice failed")
+		}
+		if len(user.Tags) != 3 || user.Tags[2] != "c" {
+			t.Errorf("tags = %v, want [a b c]", user.Tags)
+		}
+	})
+
+	t.Run("Struct values are read-only", func(t *testing.T) {
+		nav := delve.New(map[string]any{"user": *newStructUser()})
+		if got := nav.Get("user.name").String(); got != "Ann" {
+			t.Errorf("user.name = %q, want Ann", got)
+		}
+		if nav.Set("user.name", "Bob") {
+			t.Error("Set on a non-addressable struct should fail")
+		}
+	})
+
+	t.Run("Nil struct pointer is not a container", func(t *testing.T) {
+		var user *structUser
+		nav := delve.New(map[string]any{"user": user})
+		if !nav.Get("user.name").IsNil() {
+			t.Error("Get through a nil pointer should return nil")
+		}
+	})
+
+	t.Run("Nil map fields get a new map on set", func(t *testing.T) {
+		user := &structUser{}
+		nav := delve.New(map[string]any{"user": user})
+		if !nav.Set("user.extra.k", 1) {
+			t.Fatal("Set into a nil map field failed")
+		}
+		if got := user.Extra["k"]; got != 1 {
+			t.Errorf("user.Extra = %v, want map[k:1]", user.Extra)
+		}
+		typed := &struct{ Counts map[string]int }{}
+		if delve.New(map[string]any{"s": typed}).Set("s.Counts.k", 1) || typed.Counts != nil {
+			t.Errorf("Set into a nil typed map field should fail, got %v", typed.Counts)
+		}
+	})
+}
@@ -0,0 +1,153 @@
+package delve_test
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/vloldik/delve/v3"
+)
+
+func TestSyncNavigator(t *testing.T) {
+	t.Run("Concurrent QGet and QSet", func(t *testing.T) {
+		nav := delve.NewSync(map[string]any{"config": map[string]any{"version": 0}})
+		version := delve.CQ("config.version")
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(2)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					nav.QSet(delve.CQ("config.version"), i*100+j)
+					nav.Set("config.writers."+strconv.Itoa(i), j)
+				}
+			}(i)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					nav.QGet(delve.CQ("config.version")).Int()
+					nav.Get("config.writers.0").Int()
+					_, _ = nav.QGetRaw(version.Copy())
+				}
+			}()
+		}
+		wg.Wait()
+		for i := 0; i < 8; i++ {
+			if got := nav.Get("config.writers." + strconv.Itoa(i)).Int(); got != 99 {
+				t.Errorf("writer %d = %d, want 99", i, got)
+			}
+		}
+	})
+
+	t.Run("Sub-navigators share the lock", func(t *testing.T) {
+		nav := delve.NewSync(map[string]any{"list": []any{}})
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 50; j++ {
+					nav.Set("list.+", j)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 50; j++ {
+					if sub := nav.GetNavigator("list"); sub != nil {
+						sub.Get("0").Int()
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		if got := len(nav.Get("list").Interface().([]any)); got != 200 {
+			t.Errorf("list length = %d, want 200", got)
+		}
+	})
+
+	t.Run("Update is atomic for readers", func(t *testing.T) {
+		nav := delve.NewSync(map[string]any{"a": 0, "b": 0})
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 1; i <= 100; i++ {
+				_ = nav.Update(func(tx delve.Navigator) error {
+					tx.Set("a", i)
+					tx.Set("b", i)
+					return nil
+				})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				_ = nav.Update(func(tx delve.Navigator) error {
+					if a, b := tx.Get("a").Int(), tx.Get("b").Int(); a != b {
+						t.Errorf("inconsistent state a=%d b=%d", a, b)
+					}
+					return nil
+				})
+			}
+		}()
+		wg.Wait()
+	})
+
+	t.Run("Update rolls back on error", func(t *testing.T) {
+		data := map[string]any{"a": 1, "list": []any{1, 2}}
+		nav := delve.NewSync(data)
+		errAbort := errors.New("abort")
+		err := nav.Update(func(tx delve.Navigator) error {
+			tx.Set("a", 2)
+			tx.Set("new.nested.key", true)
+			tx.Set("list.+", 3)
+			tx.Delete("list.0")
+			tx.GetNavigator("list").Set("0", 20)
+			if err := tx.MergePatch(map[string]any{"merged": 1}); err != nil {
+				return err
+			}
+			return errAbort
+		})
+		if !errors.Is(err, errAbort) {
+			t.Fatalf("Update error = %v, want %v", err, errAbort)
+		}
+		want := map[string]any{"a": 1, "list": []any{1, 2}}
+		if changes := delve.Diff(delve.New(want), nav); len(changes) != 0 {
+			t.Errorf("data should be restored, got changes %v", changeStrings(changes))
+		}
+	})
+
+	t.Run("Update commits on success", func(t *testing.T) {
+		nav := delve.New(map[string]any{"a": 1})
+		err := nav.Update(func(tx delve.Navigator) error {
+			tx.SetMapSource(map[string]any{"b": 2})
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+		if got := nav.Get("b").Int(); got != 2 {
+			t.Errorf("b = %d, want 2", got)
+		}
+	})
+
+	t.Run("Merge and Diff between synchronized navigators", func(t *testing.T) {
+		a := delve.NewSync(map[string]any{"x": 1})
+		b := delve.NewSync(map[string]any{"y": 2})
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_ = a.Merge(b)
+		}()
+		go func() {
+			defer wg.Done()
+			_ = b.Merge(a)
+		}()
+		wg.Wait()
+		if changes := delve.Diff(a, b); len(changes) != 0 {
+			t.Errorf("navigators should be equal after merges, got %v", changeStrings(changes))
+		}
+	})
+}
@@ -0,0 +1,150 @@
+package delve_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/vloldik/delve/v3"
+)
+
+func TestTransaction(t *testing.T) {
+	newData := func() map[string]any {
+		return map[string]any{
+			"a":    map[string]any{"b": 1},
+			"list": []any{1, 2},
+		}
+	}
+
+	t.Run("Rollback after a failed batch", func(t *testing.T) {
+		data := newData()
+		nav := delve.New(data)
+		tx := nav.Begin()
+		ok := tx.Set("a.b", 2) &&
+			tx.Set("x.y.z", 3) &&
+			tx.Set("list.+", 3) &&
+			tx.Delete("list.0") &&
+			tx.Set("list.10", 4)
+		if ok {
+			t.Fatal("batch should fail on the out-of-range index")
+		}
+		if err := tx.Rollback(); err != nil {
+			t.Fatalf("Rollback failed: %v", err)
+		}
+		if !reflect.DeepEqual(data, newData()) {
+			t.Errorf("data should be restored, got %v", data)
+		}
+		if _, exists := data["x"]; exists {
+			t.Error("intermediate maps created by Set should be removed")
+		}
+	})
+
+	t.Run("Rollback of intermediate maps over existing values", func(t *testing.T) {
+		data := map[string]any{"a": "scalar"}
+		nav := delve.New(data)
+		tx := nav.Begin()
+		if !tx.Set("a.b.c", 1) {
+			t.Fatal("Set failed")
+		}
+		tx.Rollback()
+		if data["a"] != "scalar" {
+			t.Errorf("a = %v, want scalar", data["a"])
+		}
+	})
+
+	t.Run("Commit keeps changes", func(t *testing.T) {
+		nav := delve.New(newData())
+		tx := nav.Begin()
+		tx.Set("a.c", 2)
+		tx.SetMapSource(map[string]any{"replaced": true})
+		if err := tx.Commit(); err != nil {
+			t.Fatalf("Commit failed: %v", err)
+		}
+		if !nav.Get("replaced").Bool() {
+			t.Error("replaced source should be committed")
+		}
+		if err := tx.Commit(); !errors.Is(err, delve.ErrTxDone) {
+			t.Errorf("second Commit error = %v, want ErrTxDone", err)
+		}
+		if err := tx.Rollback(); !errors.Is(err, delve.ErrTxDone) {
+			t.Errorf("Rollback after Commit error = %v, want ErrTxDone", err)
+		}
+	})
+
+	t.Run("Rollback of patches, merges and sub-navigators", func(t *testing.T) {
+		data := newData()
+		nav := delve.New(data)
+		tx := nav.Begin()
+		if err := tx.ApplyPatch([]delve.PatchOperation{{Op: "add", Path: "/list/0", Value: 0}}); err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.Merge(delve.New(map[string]any{"a": map[string]any{"m": 1}})); err != nil {
+			t.Fatal(err)
+		}
+		tx.GetNavigator("a").Delete("b")
+		tx.SetSource(nil)
+		tx.Rollback()
+		if !reflect.DeepEqual(data, newData()) || nav.Get("a.b").Int() != 1 {
+			t.Errorf("data should be restored, got %v", data)
+		}
+	})
+
+	t.Run("Synchronized navigator is locked until the end", func(t *testing.T) {
+		nav := delve.NewSync(newData())
+		tx := nav.Begin()
+		done := make(chan struct{})
+		go func() {
+			nav.Set("a.b", 5)
+			close(done)
+		}()
+		tx.Set("a.b", 2)
+		tx.Rollback()
+		<-done
+		if got := nav.Get("a.b").Int(); got != 5 {
+			t.Errorf("a.b = %d, want 5", got)
+		}
+	})
+
+	t.Run("Update rolls back on panic", func(t *testing.T) {
+		data := newData()
+		nav := delve.NewSync(data)
+		func() {
+			defer func() { _ = recover() }()
+			_ = nav.Update(func(tx delve.Navigator) error {
+				tx.Set("a.b", 2)
+				panic("boom")
+			})
+		}()
+		if got := nav.Get("a.b").Int(); got != 1 {
+			t.Errorf("a.b = %d, want 1", got)
+		}
+	})
+
+	t.Run("Rollback of nested transactions over sub-navigators", func(t *testing.T) {
+		data := map[string]any{"a": map[string]any{"b": 1}, "b": "root"}
+		nav := delve.New(data)
+		outer := nav.Begin()
+		inner := outer.Begin()
+		inner.GetNavigator("a").Set("b", 2)
+		inner.SetSource(nil)
+		if err := inner.Commit(); err != nil {
+			t.Fatal(err)
+		}
+		outer.Rollback()
+		if want := map[string]any{"a": map[string]any{"b": 1}, "b": "root"}; !reflect.DeepEqual(data, want) || nav.Get("a.b").Int() != 1 {
+			t.Errorf("data = %v, want %v", data, want)
+		}
+
+		errFailed := errors.New("failed")
+		err := nav.Update(func(tx delve.Navigator) error {
+			_ = tx.Update(func(tx delve.Navigator) error {
+				tx.GetNavigator("a").Set("b", 3)
+				return nil
+			})
+			return errFailed
+		})
+		if want := map[string]any{"a": map[string]any{"b": 1}, "b": "root"}; err != errFailed || !reflect.DeepEqual(data, want) {
+			t.Errorf("Update() = %v, data = %v, want %v", err, data, want)
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package delve_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vloldik/delve/v3"
+)
+
+func TestTypedContainers(t *testing.T) {
+	t.Run("Typed slices", func(t *testing.T) {
+		nav := delve.New(map[string]any{"numbers": []int{1, 2, 3}})
+		if got := nav.Get("numbers.-1").Int(); got != 3 {
+			t.Errorf("numbers.-1 = %d, want 3", got)
+		}
+		if !nav.Set("numbers.0", float64(10)) {
+			t.Error("Set of a whole float to []int failed")
+		}
+		if nav.Set("numbers.0", 1.5) {
+			t.Error("Set of a fractional float to []int should fail")
+		}
+		if nav.Set("numbers.0", "x") {
+			t.Error("Set of a string to []int should fail")
+		}
+		if !nav.Set("numbers.+", int64(4)) {
+			t.Fatal("Append to []int failed")
+		}
+		if !nav.Delete("numbers.1") {
+			t.Fatal("Delete from []int failed")
+		}
+		if got := nav.Get("numbers").Interface(); !reflect.DeepEqual(got, []int{10, 3, 4}) {
+			t.Errorf("numbers = %v, want [10 3 4]", got)
+		}
+	})
+
+	t.Run("Slices of maps and structs", func(t *testing.T) {
+		users := []structAddress{{City: "Paris"}, {City: "Lyon"}}
+		nav := delve.New(map[string]any{
+			"items": []map[string]any{{"id": 1}, {"id": 2}},
+			"users": users,
+		})
+		if got := nav.Get("items.1.id").Int(); got != 2 {
+			t.Errorf("items.1.id = %d, want 2", got)
+		}
+		if !nav.Set("users.1.city", "Nice") {
+			t.Fatal("Set of a struct field in a slice failed")
+		}
+		if users[1].City != "Nice" {
+			t.Errorf("users[1].City = %q, want Nice", users[1].City)
+		}
+	})
+
+	t.Run("Arrays", func(t *testing.T) {
+		holder := &struct{ Point [2]float64 }{Point: [2]float64{1, 2}}
+		nav := delve.New(map[string]any{"array": [3]string{"a", "b", "c"}, "holder": holder})
+		if got := nav.Get("array.1").String(); got != "b" {
+			t.Errorf("array.1 = %q, want b", got)
+		}
+		if nav.Set("array.1", "x") {
+			t.Error("Set on a non-addressable array should fail")
+		}
+		if nav.Set("holder.Point.+", 3) {
+			t.Error("Append to an array should fail")
+		}
+		if !nav.Set("holder.Point.1", 5) {
+			t.Fatal("Set on an addressable array failed")
+		}
+		if holder.Point[1] != 5 {
+			t.Errorf("Point[1] = %v, want 5", holder.Point[1])
+		}
+	})
+
+	t.Run("Typed maps", func(t *testing.T) {
+		labels := map[string]string{"env": "prod"}
+		ports := map[int]uint16{80: 8080}
+		nav := delve.New(map[string]any{"labels": labels, "ports": ports})
+		if got := nav.Get("labels.env").String(); got != "prod" {
+			t.Errorf("labels.env = %q, want prod", got)
+		}
+		if nav.Set("labels.env", 1) {
+			t.Error("Set of int to map[string]string should fail")
+		}
+		if !nav.Set("labels.tier", "web") || labels["tier"] != "web" {
+			t.Error("Set to map[string]string failed")
+		}
+		if got := nav.Get("ports.80").Int(); got != 8080 {
+			t.Errorf("ports.80 = %d, want 8080", got)
+		}
+		if !nav.Set("ports.443", 8443) || ports[443] != 8443 {
+			t.Error("Set with integer key failed")
+		}
+		if nav.Set("ports.x", 1) {
+			t.Error("Set with non-integer key should fail")
+		}
+		if !nav.Delete("ports.80") {
+			t.Error("Delete with integer key failed")
+		}
+		if _, ok := ports[80]; ok {
+			t.Error("Key 80 should be removed")
+		}
+	})
+
+	t.Run("Maps with interface keys", func(t *testing.T) {
+		yaml := map[any]any{
+			"server": map[any]any{"host": "localhost", 1: "one"},
+		}
+		nav := delve.New(map[string]any{"yaml": yaml})
+		if got := nav.Get("yaml.server.host").String(); got != "localhost" {
+			t.Errorf("yaml.server.host = %q, want localhost", got)
+		}
+		if got := nav.Get("yaml.server.1").String(); got != "one" {
+			t.Errorf("yaml.server.1 = %q, want one", got)
+		}
+		if !nav.Set("yaml.server.1", "uno") {
+			t.Fatal("Set of an existing int key failed")
+		}
+		if !nav.Set("yaml.server.port", 80) {
+			t.Fatal("Set of a new key failed")
+		}
+		server := yaml["server"].(map[any]any)
+		if server[1] != "uno" || server["port"] != 80 {
+			t.Errorf("unexpected map state: %v", server)
+		}
+	})
+
+	t.Run("Typed containers in diff", func(t *testing.T) {
+		a := delve.New(map[string]any{"list": []int{1, 2}, "map": map[string]int{"a": 1}})
+		b := delve.New(map[string]any{"list": []int{1, 3}, "map": map[string]int{"a": 1, "b": 2}})
+		got := changeStrings(delve.Diff(a, b))
+		want := []string{"modified list.1 2->3", "added map.b <nil>->2"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Diff = %v, want %v", got, want)
+		}
+	})
+}
@@ -0,0 +1,140 @@
+package delve_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vloldik/delve/v3"
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
+
+func TestWalk(t *testing.T) {
+	data := map[string]any{
+		"b":      []any{1, map[string]any{"y": 2, "x": 3}},
+		"a":      map[string]any{"c": "d"},
+		"custom": deletableSource{"k": 4},
+		"ports":  []int{80},
+	}
+
+	// visited walks nav and returns "path=value" of visited values, containers are rendered as "{}"
+	visited := func(nav delve.Navigator, action func(path string) delve.WalkAction) []string {
+		var seen []string
+		nav.Walk(func(path idelve.IQual, v *delve.Value) delve.WalkAction {
+			rendered := fmt.Sprint(v.Interface())
+			switch v.Interface().(type) {
+			case map[string]any, []any, []int, deletableSource:
+				rendered = "{}"
+			}
+			seen = append(seen, fmt.Sprintf("%v=%s", path, rendered))
+			return action(fmt.Sprint(path))
+		}, delve.WalkOptions{Sorted: true})
+		return seen
+	}
+	nav := delve.New(data)
+
+	t.Run("Sorted depth-first order", func(t *testing.T) {
+		got := visited(nav, func(string) delve.WalkAction { return delve.WalkContinue })
+		want := []string{
+			"a={}", "a.c=d",
+			"b={}", "b.0=1", "b.1={}", "b.1.x=3", "b.1.y=2",
+			"custom={}",
+			"ports={}", "ports.0=80",
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("visited %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Skip and stop", func(t *testing.T) {
+		got := visited(nav, func(path string) delve.WalkAction {
+			switch path {
+			case "b":
+				return delve.WalkSkip
+			case "ports.0":
+				return delve.WalkStop
+			}
+			return delve.WalkContinue
+		})
+		want := []string{"a={}", "a.c=d", "b={}", "custom={}", "ports={}", "ports.0=80"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("visited %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Unsorted walk visits every value once", func(t *testing.T) {
+		counts := map[string]int{}
+		nav.Walk(func(path idelve.IQual, v *delve.Value) delve.WalkAction {
+			counts[fmt.Sprint(path)]++
+			return delve.WalkContinue
+		})
+		if len(counts) != 10 {
+			t.Errorf("visited %d paths, want 10: %v", len(counts), counts)
+		}
+		for path, n := range counts {
+			if n != 1 {
+				t.Errorf("%s visited %d times", path, n)
+			}
+		}
+	})
+
+	t.Run("Structs and sub-navigators", func(t *testing.T) {
+		nav := delve.New(map[string]any{"user": &structUser{Name: "Alice", Address: structAddress{City: "Paris"}}})
+		var paths []string
+		nav.GetNavigator("user").Walk(func(path idelve.IQual, v *delve.Value) delve.WalkAction {
+			paths = append(paths, fmt.Sprint(path))
+			return delve.WalkContinue
+		}, delve.WalkOptions{Sorted: true})
+		for _, want := range []string{"name", "address", "address.city"} {
+			found := false
+			for _, path := range paths {
+				found = found || path == want
+			}
+			if !found {
+				t.Errorf("path %q was not visited: %v", want, paths)
+			}
+		}
+	})
+
+	t.Run("Visit can use a synchronized navigator", func(t *testing.T) {
+		nav := delve.NewSync(map[string]any{"a": map[string]any{"b": 1}, "list": []any{1, 2, 3}})
+		stop := make(chan struct{})
+		defer close(stop)
+		go func() {
+			// a waiting writer blocks new read locks, which deadlocks recursive ones
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					nav.Set("counter", 1)
+				}
+			}
+		}()
+
+		done := make(chan int)
+		go func() {
+			visited := 0
+			for range 100 {
+				nav.Walk(func(path idelve.IQual, v *delve.Value) delve.WalkAction {
+					_ = nav.QGet(path)
+					if fmt.Sprint(path) == "a.b" {
+						nav.Set("a.b", 2)
+					}
+					visited++
+					return delve.WalkContinue
+				})
+			}
+			done <- visited
+		}()
+		select {
+		case visited := <-done:
+			if visited < 600 {
+				t.Errorf("visited %d values, want at least 600", visited)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatal("Walk deadlocked")
+		}
+	})
+}
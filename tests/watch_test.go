@@ -0,0 +1,108 @@
+package delve_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/vloldik/delve/v3"
+)
+
+// recordWatch subscribes to path and appends "name: old -> new" to events on every notification
+func recordWatch(nav delve.Navigator, path, name string, events *[]string) func() {
+	return nav.Watch(path, func(old, new *delve.Value) {
+		*events = append(*events, fmt.Sprintf("%s: %v -> %v", name, old.Interface(), new.Interface()))
+	})
+}
+
+func TestWatch(t *testing.T) {
+	t.Run("Exact path and prefix", func(t *testing.T) {
+		nav := delve.New(map[string]any{"db": map[string]any{"dsn": "a"}, "other": 1})
+		var events []string
+		recordWatch(nav, "db.dsn", "dsn", &events)
+		recordWatch(nav, "db", "db", &events)
+		recordWatch(nav, "other", "other", &events)
+
+		nav.Set("db.dsn", "b")
+		want := []string{"dsn: a -> b", "db: map[dsn:a] -> map[dsn:b]"}
+		if !reflect.DeepEqual(events, want) {
+			t.Errorf("events = %v, want %v", events, want)
+		}
+	})
+
+	t.Run("Parent changes, deletes and unchanged values", func(t *testing.T) {
+		nav := delve.New(map[string]any{"db": map[string]any{"dsn": "a"}})
+		var events []string
+		recordWatch(nav, "db.dsn", "dsn", &events)
+
+		nav.Set("db.dsn", "a")
+		nav.Set("db", map[string]any{"dsn": "c"})
+		nav.Delete("db")
+		nav.Set("db.dsn", "d")
+		want := []string{"dsn: a -> c", "dsn: c -> <nil>", "dsn: <nil> -> d"}
+		if !reflect.DeepEqual(events, want) {
+			t.Errorf("events = %v, want %v", events, want)
+		}
+	})
+
+	t.Run("Unsubscribe", func(t *testing.T) {
+		nav := delve.New(map[string]any{"a": 1})
+		var events []string
+		unwatch := recordWatch(nav, "a", "a", &events)
+		nav.Set("a", 2)
+		unwatch()
+		nav.Set("a", 3)
+		if want := []string{"a: 1 -> 2"}; !reflect.DeepEqual(events, want) {
+			t.Errorf("events = %v, want %v", events, want)
+		}
+	})
+
+	t.Run("Merges, patches, list shifts and sub-navigators", func(t *testing.T) {
+		nav := delve.New(map[string]any{"list": []any{"x", "y"}, "m": map[string]any{}})
+		var events []string
+		recordWatch(nav, "list.1", "second", &events)
+		recordWatch(nav, "m.k", "k", &events)
+
+		nav.Delete("list.0")
+		_ = nav.MergePatch(map[string]any{"m": map[string]any{"k": 1}})
+		_ = nav.ApplyPatch([]delve.PatchOperation{{Op: "add", Path: "/list/0", Value: "w"}})
+		nav.GetNavigator("m").Set("k", 2)
+		want := []string{"second: y -> <nil>", "k: <nil> -> 1", "second: <nil> -> y", "k: 1 -> 2"}
+		if !reflect.DeepEqual(events, want) {
+			t.Errorf("events = %v, want %v", events, want)
+		}
+	})
+
+	t.Run("Transactions notify on commit", func(t *testing.T) {
+		nav := delve.NewSync(map[string]any{"a": 1, "b": 1})
+		var events []string
+		nav.Watch("", func(old, new *delve.Value) {}) // root-level watcher must not deadlock
+		recordWatch(nav, "a", "a", &events)
+		recordWatch(nav, "b", "b", &events)
+
+		tx := nav.Begin()
+		tx.Set("a", 2)
+		tx.Rollback()
+		_ = nav.Update(func(tx delve.Navigator) error {
+			tx.Set("b", 2)
+			tx.Set("a", 3)
+			return nil
+		})
+		want := []string{"a: 1 -> 3", "b: 1 -> 2"}
+		if !reflect.DeepEqual(events, want) {
+			t.Errorf("events = %v, want %v", events, want)
+		}
+	})
+
+	t.Run("Callbacks can use a synchronized navigator", func(t *testing.T) {
+		nav := delve.NewSync(map[string]any{"a": 1})
+		var seen int
+		nav.Watch("a", func(old, new *delve.Value) {
+			seen = nav.Get("a").Int()
+		})
+		nav.Set("a", 5)
+		if seen != 5 {
+			t.Errorf("seen = %d, want 5", seen)
+		}
+	})
+}
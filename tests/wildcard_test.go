@@ -0,0 +1,111 @@
+package delve_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/vloldik/delve/v3"
+	"github.com/vloldik/delve/v3/internal/quals"
+	"github.com/vloldik/delve/v3/pkg/idelve"
+)
+
+func matchStrings(matches []delve.Match) []string {
+	result := make([]string, 0, len(matches))
+	for _, m := range matches {
+		result = append(result, fmt.Sprintf("%v=%v", m.Path, m.Value.Interface()))
+	}
+	slices.Sort(result)
+	return result
+}
+
+func TestGetAll(t *testing.T) {
+	nav := delve.New(map[string]any{
+		"items": []any{
+			map[string]any{"id": 1},
+			map[string]any{"id": 2, "name": "b"},
+			map[string]any{"name": "c"},
+			"scalar",
+		},
+		"groups": map[string]any{
+			"x": map[string]any{"id": 3},
+			"y": map[string]any{"id": 4},
+		},
+		"*": map[string]any{"id": 5},
+	})
+
+	for _, qual := range []idelve.IQual{quals.Q("items.*.id"), quals.CQ("items.*.id")} {
+		if got := matchStrings(nav.QGetAll(qual)); !slices.Equal(got, []string{"items.0.id=1", "items.1.id=2"}) {
+			t.Errorf("Unexpected matches %v", got)
+		}
+	}
+
+	if got := matchStrings(nav.GetAll("*.*.id")); !slices.Equal(got, []string{"groups.x.id=3", "groups.y.id=4", "items.0.id=1", "items.1.id=2"}) {
+		t.Errorf("Unexpected matches %v", got)
+	}
+
+	if got := matchStrings(nav.GetAll("*.id")); !slices.Equal(got, []string{"\\*.id=5"}) {
+		t.Errorf("Unexpected matches %v", got)
+	}
+
+	for _, qual := range []idelve.IQual{quals.Q("\\*.id"), quals.CQ("\\*.id")} {
+		if got := matchStrings(nav.QGetAll(qual)); !slices.Equal(got, []string{"\\*.id=5"}) {
+			t.Errorf("Escaped wildcard should match literal key, got %v", got)
+		}
+	}
+
+	if got := nav.GetAll("groups.missing.*"); len(got) != 0 {
+		t.Errorf("Expected no matches, got %v", matchStrings(got))
+	}
+
+	matches := nav.GetAll("groups.*.id")
+	for _, m := range matches {
+		if nav.QGet(m.Path).Int() != m.Value.Int() {
+			t.Errorf("Match path %v should point to its value", m.Path)
+		}
+	}
+}
+
+func TestWildcardIsKeyForSingleGet(t *testing.T) {
+	nav := delve.New(map[string]any{"*": 1})
+	if nav.Get("*").Int() != 1 || nav.QGet(quals.CQ("*")).Int() != 1 {
+		t.Error("Single value get should treat wildcard as a key")
+	}
+}
+
+func TestCompiledQualEscapedWildcardString(t *testing.T) {
+	testString := `a.*.\*.b*`
+	if qual := quals.CQ(testString); qual.String() != testString {
+		t.Fatalf("String %s is not equals %s", qual.String(), testString)
+	}
+}
+
+func TestRecursiveDescent(t *testing.T) {
+	nav := delve.New(map[string]any{
+		"error": map[string]any{"code": 1},
+		"data": []any{
+			map[string]any{"error": map[string]any{"code": 2}},
+			map[string]any{"nested": map[string]any{"deep": map[string]any{"error": map[string]any{"code": 3}}}},
+			map[string]any{"error": "plain"},
+		},
+	})
+
+	expected := []string{"data.0.error.code=2", "data.1.nested.deep.error.code=3", "error.code=1"}
+	for _, qual := range []idelve.IQual{quals.Q("**.error.code"), quals.CQ("**.error.code"), quals.CQ("**.**.error.code")} {
+		if got := matchStrings(nav.QGetAll(qual)); !slices.Equal(got, expected) {
+			t.Errorf("Unexpected matches %v", got)
+		}
+	}
+
+	if got := matchStrings(nav.GetAll("data.**.code")); !slices.Equal(got, []string{"data.0.error.code=2", "data.1.nested.deep.error.code=3"}) {
+		t.Errorf("Unexpected matches %v", got)
+	}
+
+	if got := nav.GetAll("data.1.**"); len(got) != 4 {
+		t.Errorf("Trailing recursive segment should match every nested value, got %v", matchStrings(got))
+	}
+
+	if got := nav.GetAll("\\*\\*.error.code"); len(got) != 0 {
+		t.Errorf("Escaped recursive segment should be a key, got %v", matchStrings(got))
+	}
+}